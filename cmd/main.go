@@ -9,6 +9,7 @@ import (
 	"log"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/provider"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/tracing"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -28,7 +29,20 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	// Optional OpenTelemetry tracing, enabled through environment variables
+	shutdownTracing, err := tracing.Init(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("failed to flush traces: %s", shutdownErr.Error())
+	}
+
 	if err != nil {
 		log.Fatal(err.Error())
 	}
@@ -5,9 +5,11 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
+	"encoding/json"
 	"fmt"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // -------------------- Plugin Framework functions (new) --------------------
@@ -52,6 +54,43 @@ func ReadVlan(ctx context.Context, c *client.CrucibleClient, id string) (*struct
 	return vlan, nil
 }
 
+// FindVlanInPartition returns the ID of the VLAN with the given VLAN number in a
+// partition. The returned error wraps client.ErrNotFound if there is none.
+func FindVlanInPartition(ctx context.Context, c *client.CrucibleClient, partitionID string, vlanID int) (string, error) {
+	url := c.GetCasterAPIURL() + "partitions/" + partitionID + "/vlans"
+	var id string
+
+	err := c.DoList(ctx, url, func(page json.RawMessage) error {
+		var vlans []structs.Vlan
+		if err := json.Unmarshal(page, &vlans); err != nil {
+			return fmt.Errorf("failed to decode VLANs: %w", err)
+		}
+
+		for _, vlan := range vlans {
+			if vlan.VlanId == vlanID {
+				id = vlan.Id
+				return client.ErrStopPaging
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get VLANs in partition %s: %w", partitionID, err)
+	}
+
+	if id == "" {
+		return "", fmt.Errorf("VLAN %d in partition %s: %w", vlanID, partitionID, client.ErrNotFound)
+	}
+
+	return id, nil
+}
+
+// ListVlansInPartition passes each VLAN in a partition to handle, stopping
+// early if handle returns client.ErrStopPaging.
+func ListVlansInPartition(ctx context.Context, c *client.CrucibleClient, partitionID string, handle func(vlan structs.Vlan) error) error {
+	return listEach(ctx, c, c.GetCasterAPIURL()+"partitions/"+partitionID+"/vlans", "VLAN", handle)
+}
+
 // DeleteVlan releases a VLAN back to the pool using the centralized client.
 func DeleteVlan(ctx context.Context, c *client.CrucibleClient, id string) error {
 	url := c.GetCasterAPIURL() + "vlans/" + id + "/actions/release"
@@ -0,0 +1,77 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+)
+
+// ErrAmbiguous is returned when a name matches more than one object, so the
+// object must be identified by ID instead.
+var ErrAmbiguous = errors.New("name is ambiguous")
+
+// findIDByName lists the collection at url and returns the ID of the single
+// object of the given kind whose name is name. The returned error wraps
+// client.ErrNotFound if nothing matches and ErrAmbiguous if several objects do.
+func findIDByName(ctx context.Context, c *client.CrucibleClient, url, kind, name string) (string, error) {
+	var ids []string
+
+	err := c.DoList(ctx, url, func(page json.RawMessage) error {
+		var objects []map[string]interface{}
+		if err := json.Unmarshal(page, &objects); err != nil {
+			return fmt.Errorf("failed to decode %ss: %w", kind, err)
+		}
+
+		for _, object := range objects {
+			if object["name"] == name {
+				if id, ok := object["id"].(string); ok {
+					ids = append(ids, id)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get %ss: %w", kind, err)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%s '%s': %w", kind, name, client.ErrNotFound)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%d %ss are named '%s' (%s): %w", len(ids), kind, name, strings.Join(ids, ", "), ErrAmbiguous)
+	}
+}
+
+// listEach lists the collection at url and passes each object of the given kind
+// to handle. Listing stops early, without an error, when handle returns
+// client.ErrStopPaging.
+func listEach[T any](ctx context.Context, c *client.CrucibleClient, url, kind string, handle func(object T) error) error {
+	err := c.DoList(ctx, url, func(page json.RawMessage) error {
+		var objects []T
+		if err := json.Unmarshal(page, &objects); err != nil {
+			return fmt.Errorf("failed to decode %ss: %w", kind, err)
+		}
+
+		for _, object := range objects {
+			if err := handle(object); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list %ss: %w", kind, err)
+	}
+
+	return nil
+}
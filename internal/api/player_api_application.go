@@ -5,9 +5,10 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
 	"fmt"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // -------------------- Plugin Framework functions (new) --------------------
@@ -5,10 +5,10 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
 	"fmt"
-	"net/http"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // --------------------- Plugin Framework functions (new) ---------------------
@@ -57,6 +57,19 @@ func AppTemplateRead(ctx context.Context, c *client.CrucibleClient, id string) (
 	return template, nil
 }
 
+// FindAppTemplateByName returns the ID of the application template with the
+// given name. The returned error wraps client.ErrNotFound if no template
+// matches and ErrAmbiguous if several do.
+func FindAppTemplateByName(ctx context.Context, c *client.CrucibleClient, name string) (string, error) {
+	return findIDByName(ctx, c, c.GetPlayerAPIURL()+"application-templates", "application template", name)
+}
+
+// ListAppTemplates passes each application template to handle, stopping early
+// if handle returns client.ErrStopPaging.
+func ListAppTemplates(ctx context.Context, c *client.CrucibleClient, handle func(template structs.AppTemplate) error) error {
+	return listEach(ctx, c, c.GetPlayerAPIURL()+"application-templates", "application template", handle)
+}
+
 // AppTemplateUpdate updates an existing application template using the centralized client.
 func AppTemplateUpdate(ctx context.Context, c *client.CrucibleClient, id string, template *structs.AppTemplate) error {
 	// Build payload
@@ -90,16 +103,3 @@ func DeleteAppTemplate(ctx context.Context, c *client.CrucibleClient, id string)
 	}
 	return nil
 }
-
-// AppTemplateExists checks if an application template exists using the centralized client.
-func AppTemplateExists(ctx context.Context, c *client.CrucibleClient, id string) (bool, error) {
-	url := c.GetPlayerAPIURL() + "application-templates/" + id
-	resp, err := c.DoRequest(ctx, "GET", url, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to check application template existence: %w", err)
-	}
-	defer resp.Body.Close()
-
-	return resp.StatusCode == http.StatusOK, nil
-}
-
@@ -5,82 +5,98 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
+	"encoding/json"
+	"errors"
 	"fmt"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // -------------------- Plugin Framework functions (new) --------------------
 
 // CreateTeams creates teams in a view using the centralized client.
+// Teams, and the users and application instances within each team, are created
+// in parallel up to the client's request limit. Every team is attempted even if
+// others fail, and all errors are returned together. IDs are stored in place,
+// so the order of teams, users and instances is preserved.
 func CreateTeams(ctx context.Context, c *client.CrucibleClient, teams *[]structs.TeamInfo, viewID string) error {
-	for i := range *teams {
-		team := &(*teams)[i]
+	return c.ForEach(ctx, len(*teams), func(ctx context.Context, i int) error {
+		return createTeam(ctx, c, &(*teams)[i], viewID)
+	})
+}
 
-		payload := map[string]interface{}{
-			"name": team.Name,
-			"role": team.Role,
-		}
+// createTeam creates a single team with its users and application instances.
+func createTeam(ctx context.Context, c *client.CrucibleClient, team *structs.TeamInfo, viewID string) error {
+	payload := map[string]interface{}{
+		"name": team.Name,
+		"role": team.Role,
+	}
 
-		url := c.GetPlayerAPIURL() + "views/" + viewID + "/teams"
-		var result map[string]interface{}
+	url := c.GetPlayerAPIURL() + "views/" + viewID + "/teams"
+	var result map[string]interface{}
 
-		if err := c.DoPost(ctx, url, payload, &result); err != nil {
-			return fmt.Errorf("failed to create team '%v': %w", team.Name, err)
-		}
+	if err := c.DoPost(ctx, url, payload, &result); err != nil {
+		return fmt.Errorf("failed to create team '%v': %w", team.Name, err)
+	}
 
-		// Store the team ID
-		if id, ok := result["id"].(string); ok {
-			team.ID = id
-		}
+	// Store the team ID
+	teamID, ok := result["id"].(string)
+	if !ok {
+		return fmt.Errorf("team ID not found in API response for team '%v'", team.Name)
+	}
+	team.ID = teamID
 
-		// Add users to team
-		if len(team.Users) > 0 {
-			for _, user := range team.Users {
-				userURL := c.GetPlayerAPIURL() + "teams/" + team.ID.(string) + "/users/" + user.ID
-				if err := c.DoPost(ctx, userURL, nil, nil); err != nil {
-					return fmt.Errorf("failed to add user to team: %w", err)
-				}
+	// Add users to team
+	usersErr := c.ForEach(ctx, len(team.Users), func(ctx context.Context, j int) error {
+		user := team.Users[j]
 
-				// Set user role if specified
-				if userRole, ok := user.Role.(string); ok && userRole != "" {
-					if err := setUserRoleInTeam(ctx, c, user.ID, team.ID.(string), viewID, userRole); err != nil {
-						return fmt.Errorf("failed to set user role: %w", err)
-					}
-				}
+		userURL := c.GetPlayerAPIURL() + "teams/" + teamID + "/users/" + user.ID
+		if err := c.DoPost(ctx, userURL, nil, nil); err != nil {
+			return fmt.Errorf("failed to add user %s to team '%v': %w", user.ID, team.Name, err)
+		}
+
+		// Set user role if specified
+		if userRole, ok := user.Role.(string); ok && userRole != "" {
+			if err := setUserRoleInTeam(ctx, c, user.ID, teamID, viewID, userRole); err != nil {
+				return fmt.Errorf("failed to set role of user %s in team '%v': %w", user.ID, team.Name, err)
 			}
 		}
 
-		// Add app instances to team
-		if len(team.AppInstances) > 0 {
-			for j, instance := range team.AppInstances {
-				// Find the application ID by name
-				appID, err := getAppIDByName(ctx, c, viewID, instance.Name)
-				if err != nil {
-					return fmt.Errorf("failed to find application '%s': %w", instance.Name, err)
-				}
+		return nil
+	})
 
-				instancePayload := map[string]interface{}{
-					"applicationId": appID,
-					"displayOrder":  instance.DisplayOrder,
-				}
+	// Add app instances to team
+	instancesErr := c.ForEach(ctx, len(team.AppInstances), func(ctx context.Context, j int) error {
+		instance := &team.AppInstances[j]
 
-				instURL := c.GetPlayerAPIURL() + "teams/" + team.ID.(string) + "/application-instances"
-				var instResult map[string]interface{}
+		// Find the application ID by name
+		appID, err := getAppIDByName(ctx, c, viewID, instance.Name)
+		if err != nil {
+			return fmt.Errorf("failed to find application '%s': %w", instance.Name, err)
+		}
 
-				if err := c.DoPost(ctx, instURL, instancePayload, &instResult); err != nil {
-					return fmt.Errorf("failed to create application instance: %w", err)
-				}
+		instancePayload := map[string]interface{}{
+			"applicationId": appID,
+			"displayOrder":  instance.DisplayOrder,
+		}
 
-				// Store the instance ID
-				if id, ok := instResult["id"].(string); ok {
-					team.AppInstances[j].ID = id
-				}
-			}
+		instURL := c.GetPlayerAPIURL() + "teams/" + teamID + "/application-instances"
+		var instResult map[string]interface{}
+
+		if err := c.DoPost(ctx, instURL, instancePayload, &instResult); err != nil {
+			return fmt.Errorf("failed to create application instance '%s' in team '%v': %w", instance.Name, team.Name, err)
 		}
-	}
 
-	return nil
+		// Store the instance ID
+		if id, ok := instResult["id"].(string); ok {
+			instance.ID = id
+		}
+
+		return nil
+	})
+
+	return errors.Join(usersErr, instancesErr)
 }
 
 // AddPermissionsToTeam adds permissions to teams using the centralized client.
@@ -109,6 +125,10 @@ func AddPermissionsToTeam(ctx context.Context, c *client.CrucibleClient, teams *
 
 			url := c.GetPlayerAPIURL() + "team-permissions"
 			if err := c.DoPost(ctx, url, payload, nil); err != nil {
+				// The cached permission ID may be stale; look it up again next time
+				if errors.Is(err, client.ErrNotFound) {
+					c.InvalidateLookup(client.LookupPermission, permName)
+				}
 				return fmt.Errorf("failed to add permission '%s' to team: %w", permName, err)
 			}
 		}
@@ -121,18 +141,24 @@ func AddPermissionsToTeam(ctx context.Context, c *client.CrucibleClient, teams *
 func setUserRoleInTeam(ctx context.Context, c *client.CrucibleClient, userID, teamID, viewID, roleName string) error {
 	// Find the membership ID
 	membershipURL := c.GetPlayerAPIURL() + "users/" + userID + "/views/" + viewID + "/team-memberships"
-	var memberships []map[string]interface{}
-
-	if err := c.DoGet(ctx, membershipURL, &memberships); err != nil {
-		return fmt.Errorf("failed to get team memberships: %w", err)
-	}
 
 	var membershipID string
-	for _, membership := range memberships {
-		if membership["teamId"] == teamID {
-			membershipID = membership["id"].(string)
-			break
+	err := c.DoList(ctx, membershipURL, func(page json.RawMessage) error {
+		var memberships []map[string]interface{}
+		if err := json.Unmarshal(page, &memberships); err != nil {
+			return fmt.Errorf("failed to decode team memberships: %w", err)
+		}
+
+		for _, membership := range memberships {
+			if membership["teamId"] == teamID {
+				membershipID, _ = membership["id"].(string)
+				return client.ErrStopPaging
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get team memberships: %w", err)
 	}
 
 	if membershipID == "" {
@@ -152,6 +178,10 @@ func setUserRoleInTeam(ctx context.Context, c *client.CrucibleClient, userID, te
 
 	url := c.GetPlayerAPIURL() + "team-memberships/" + membershipID
 	if err := c.DoPut(ctx, url, payload); err != nil {
+		// The cached role ID may be stale; look it up again next time
+		if errors.Is(err, client.ErrNotFound) {
+			c.InvalidateLookup(client.LookupRole, roleName)
+		}
 		return fmt.Errorf("failed to set user role: %w", err)
 	}
 
@@ -159,39 +189,53 @@ func setUserRoleInTeam(ctx context.Context, c *client.CrucibleClient, userID, te
 }
 
 // getPermissionIDByName looks up a permission ID by name using the centralized client.
+// Results are cached by the client.
 func getPermissionIDByName(ctx context.Context, c *client.CrucibleClient, permName string) (string, error) {
-	url := c.GetPlayerAPIURL() + "permissions/name/" + permName
+	return c.Lookup(ctx, client.LookupPermission, permName, func(ctx context.Context) (string, error) {
+		url := c.GetPlayerAPIURL() + "permissions/name/" + permName
 
-	var result map[string]interface{}
-	if err := c.DoGet(ctx, url, &result); err != nil {
-		return "", fmt.Errorf("failed to lookup permission '%s': %w", permName, err)
-	}
+		var result map[string]interface{}
+		if err := c.DoGet(ctx, url, &result); err != nil {
+			return "", fmt.Errorf("failed to lookup permission '%s': %w", permName, err)
+		}
 
-	permID, ok := result["id"].(string)
-	if !ok {
-		return "", fmt.Errorf("permission ID not found for '%s'", permName)
-	}
+		permID, ok := result["id"].(string)
+		if !ok {
+			return "", fmt.Errorf("permission ID not found for '%s'", permName)
+		}
 
-	return permID, nil
+		return permID, nil
+	})
 }
 
 // getAppIDByName looks up an application ID by name within a view using the centralized client.
 func getAppIDByName(ctx context.Context, c *client.CrucibleClient, viewID, appName string) (string, error) {
 	url := c.GetPlayerAPIURL() + "views/" + viewID + "/applications"
 
-	var apps []map[string]interface{}
-	if err := c.DoGet(ctx, url, &apps); err != nil {
-		return "", fmt.Errorf("failed to get applications: %w", err)
-	}
+	var appID string
+	err := c.DoList(ctx, url, func(page json.RawMessage) error {
+		var apps []map[string]interface{}
+		if err := json.Unmarshal(page, &apps); err != nil {
+			return fmt.Errorf("failed to decode applications: %w", err)
+		}
 
-	for _, app := range apps {
-		if app["name"] == appName {
-			if id, ok := app["id"].(string); ok {
-				return id, nil
+		for _, app := range apps {
+			if app["name"] == appName {
+				if id, ok := app["id"].(string); ok {
+					appID = id
+					return client.ErrStopPaging
+				}
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get applications: %w", err)
 	}
 
-	return "", fmt.Errorf("application '%s' not found in view %s", appName, viewID)
-}
+	if appID == "" {
+		return "", fmt.Errorf("application '%s' not found in view %s", appName, viewID)
+	}
 
+	return appID, nil
+}
@@ -5,10 +5,11 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
+	"errors"
 	"fmt"
-	"net/http"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // ---------------------- Plugin Framework functions (new) ----------------------
@@ -17,7 +18,8 @@ import (
 func CreateUser(ctx context.Context, c *client.CrucibleClient, user *structs.PlayerUser) error {
 	// If a role was set, find its ID. Otherwise set role field to nil
 	var roleID interface{} = nil
-	if roleStr, ok := user.Role.(string); ok && roleStr != "" {
+	roleName := user.Role
+	if roleStr, ok := roleName.(string); ok && roleStr != "" {
 		role, err := getRoleByNameWithClient(ctx, c, roleStr)
 		if err != nil {
 			return fmt.Errorf("failed to resolve role '%s': %w", roleStr, err)
@@ -29,6 +31,10 @@ func CreateUser(ctx context.Context, c *client.CrucibleClient, user *structs.Pla
 	url := c.GetPlayerAPIURL() + "users"
 	var result map[string]interface{}
 	if err := c.DoPost(ctx, url, user, &result); err != nil {
+		// The cached role ID may be stale; look it up again next time
+		if roleStr, ok := roleName.(string); ok && errors.Is(err, client.ErrNotFound) {
+			c.InvalidateLookup(client.LookupRole, roleStr)
+		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
 
@@ -47,11 +53,30 @@ func ReadUser(ctx context.Context, c *client.CrucibleClient, id string) (*struct
 	return user, nil
 }
 
+// FindUserByName returns the ID of the user with the given name. The returned
+// error wraps client.ErrNotFound if no user matches and ErrAmbiguous if
+// several do.
+func FindUserByName(ctx context.Context, c *client.CrucibleClient, name string) (string, error) {
+	return findIDByName(ctx, c, c.GetPlayerAPIURL()+"users", "user", name)
+}
+
+// ListUsers passes each user to handle, stopping early if handle returns
+// client.ErrStopPaging. If teamID is not empty, only members of that team are
+// listed.
+func ListUsers(ctx context.Context, c *client.CrucibleClient, teamID string, handle func(user structs.PlayerUser) error) error {
+	url := c.GetPlayerAPIURL() + "users"
+	if teamID != "" {
+		url = c.GetPlayerAPIURL() + "teams/" + teamID + "/users"
+	}
+	return listEach(ctx, c, url, "user", handle)
+}
+
 // UpdateUser updates an existing player user using the centralized client.
 func UpdateUser(ctx context.Context, c *client.CrucibleClient, user *structs.PlayerUser) error {
 	// If a role was set, find its ID. Otherwise set role field to nil
 	var roleID interface{} = nil
-	if roleStr, ok := user.Role.(string); ok && roleStr != "" {
+	roleName := user.Role
+	if roleStr, ok := roleName.(string); ok && roleStr != "" {
 		role, err := getRoleByNameWithClient(ctx, c, roleStr)
 		if err != nil {
 			return fmt.Errorf("failed to resolve role '%s': %w", roleStr, err)
@@ -62,6 +87,10 @@ func UpdateUser(ctx context.Context, c *client.CrucibleClient, user *structs.Pla
 
 	url := c.GetPlayerAPIURL() + "users/" + user.ID
 	if err := c.DoPut(ctx, url, user); err != nil {
+		// The cached role ID may be stale; look it up again next time
+		if roleStr, ok := roleName.(string); ok && errors.Is(err, client.ErrNotFound) {
+			c.InvalidateLookup(client.LookupRole, roleStr)
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -77,50 +106,42 @@ func DeleteUser(ctx context.Context, c *client.CrucibleClient, id string) error
 	return nil
 }
 
-// UserExists checks if a player user exists using the centralized client.
-func UserExists(ctx context.Context, c *client.CrucibleClient, id string) (bool, error) {
-	url := c.GetPlayerAPIURL() + "users/" + id
-	resp, err := c.DoRequest(ctx, "GET", url, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to check user existence: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// User exists if we get 200, doesn't exist if 404
-	return resp.StatusCode == http.StatusOK, nil
-}
-
 // getRoleByNameWithClient looks up a role ID by name using the centralized client.
+// Results are cached by the client.
 func getRoleByNameWithClient(ctx context.Context, c *client.CrucibleClient, roleName string) (string, error) {
-	url := c.GetPlayerAPIURL() + "roles/name/" + roleName
+	return c.Lookup(ctx, client.LookupRole, roleName, func(ctx context.Context) (string, error) {
+		url := c.GetPlayerAPIURL() + "roles/name/" + roleName
 
-	var result map[string]interface{}
-	if err := c.DoGet(ctx, url, &result); err != nil {
-		return "", fmt.Errorf("failed to lookup role '%s': %w", roleName, err)
-	}
+		var result map[string]interface{}
+		if err := c.DoGet(ctx, url, &result); err != nil {
+			return "", fmt.Errorf("failed to lookup role '%s': %w", roleName, err)
+		}
 
-	roleID, ok := result["id"].(string)
-	if !ok {
-		return "", fmt.Errorf("role ID not found in response for role '%s'", roleName)
-	}
+		roleID, ok := result["id"].(string)
+		if !ok {
+			return "", fmt.Errorf("role ID not found in response for role '%s'", roleName)
+		}
 
-	return roleID, nil
+		return roleID, nil
+	})
 }
 
 // GetRoleByID returns the name of the role with the given ID using the centralized client.
+// Results are cached by the client.
 func GetRoleByID(ctx context.Context, c *client.CrucibleClient, roleID string) (string, error) {
-	url := c.GetPlayerAPIURL() + "roles/" + roleID
+	return c.Lookup(ctx, client.LookupRoleName, roleID, func(ctx context.Context) (string, error) {
+		url := c.GetPlayerAPIURL() + "roles/" + roleID
 
-	var result map[string]interface{}
-	if err := c.DoGet(ctx, url, &result); err != nil {
-		return "", fmt.Errorf("failed to lookup role by ID '%s': %w", roleID, err)
-	}
+		var result map[string]interface{}
+		if err := c.DoGet(ctx, url, &result); err != nil {
+			return "", fmt.Errorf("failed to lookup role by ID '%s': %w", roleID, err)
+		}
 
-	roleName, ok := result["name"].(string)
-	if !ok {
-		return "", fmt.Errorf("role name not found in response for role ID '%s'", roleID)
-	}
+		roleName, ok := result["name"].(string)
+		if !ok {
+			return "", fmt.Errorf("role name not found in response for role ID '%s'", roleID)
+		}
 
-	return roleName, nil
+		return roleName, nil
+	})
 }
-
@@ -5,10 +5,10 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
 	"fmt"
-	"net/http"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // -------------------- Plugin Framework functions (new) --------------------
@@ -56,6 +56,19 @@ func ReadView(ctx context.Context, c *client.CrucibleClient, id string) (*struct
 	return view, nil
 }
 
+// FindViewByName returns the ID of the view with the given name. The returned
+// error wraps client.ErrNotFound if no view matches and ErrAmbiguous if
+// several do.
+func FindViewByName(ctx context.Context, c *client.CrucibleClient, name string) (string, error) {
+	return findIDByName(ctx, c, c.GetPlayerAPIURL()+"views", "view", name)
+}
+
+// ListViews passes each view to handle, stopping early if handle returns
+// client.ErrStopPaging.
+func ListViews(ctx context.Context, c *client.CrucibleClient, handle func(view structs.ViewInfo) error) error {
+	return listEach(ctx, c, c.GetPlayerAPIURL()+"views", "view", handle)
+}
+
 // UpdateView updates a view's metadata using the centralized client.
 func UpdateView(ctx context.Context, c *client.CrucibleClient, id string, view *structs.ViewInfo) error {
 	payload := map[string]interface{}{
@@ -83,15 +96,3 @@ func DeleteView(ctx context.Context, c *client.CrucibleClient, id string) error
 	}
 	return nil
 }
-
-// ViewExists checks if a view exists using the centralized client.
-func ViewExists(ctx context.Context, c *client.CrucibleClient, id string) (bool, error) {
-	url := c.GetPlayerAPIURL() + "views/" + id
-	resp, err := c.DoRequest(ctx, "GET", url, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to check view existence: %w", err)
-	}
-	defer resp.Body.Close()
-
-	return resp.StatusCode == http.StatusOK, nil
-}
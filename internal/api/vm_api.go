@@ -5,10 +5,10 @@ package api
 
 import (
 	"context"
-	"crucible_provider/internal/client"
-	"crucible_provider/internal/structs"
 	"fmt"
-	"net/http"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
 )
 
 // -------------------- Plugin Framework functions (new) --------------------
@@ -42,6 +42,24 @@ func GetVMInfo(ctx context.Context, c *client.CrucibleClient, id string) (*struc
 	return vmInfo, nil
 }
 
+// FindVMByName returns the ID of the virtual machine with the given name. The
+// returned error wraps client.ErrNotFound if no VM matches and ErrAmbiguous if
+// several do.
+func FindVMByName(ctx context.Context, c *client.CrucibleClient, name string) (string, error) {
+	return findIDByName(ctx, c, c.GetVMAPIURL()+"vms", "VM", name)
+}
+
+// ListVMs passes each virtual machine to handle, stopping early if handle
+// returns client.ErrStopPaging. If teamID is not empty, only VMs in that team
+// are listed.
+func ListVMs(ctx context.Context, c *client.CrucibleClient, teamID string, handle func(vm structs.VMInfo) error) error {
+	url := c.GetVMAPIURL() + "vms"
+	if teamID != "" {
+		url = c.GetVMAPIURL() + "teams/" + teamID + "/vms"
+	}
+	return listEach(ctx, c, url, "VM", handle)
+}
+
 // UpdateVM updates an existing virtual machine using the centralized client.
 func UpdateVM(ctx context.Context, c *client.CrucibleClient, vmInfo *structs.VMInfo) error {
 	url := c.GetVMAPIURL() + "vms/" + vmInfo.ID
@@ -64,18 +82,6 @@ func DeleteVM(ctx context.Context, c *client.CrucibleClient, id string) error {
 	return nil
 }
 
-// VMExists checks if a virtual machine exists using the centralized client.
-func VMExists(ctx context.Context, c *client.CrucibleClient, id string) (bool, error) {
-	url := c.GetVMAPIURL() + "vms/" + id
-	resp, err := c.DoRequest(ctx, "GET", url, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to check VM existence: %w", err)
-	}
-	defer resp.Body.Close()
-
-	return resp.StatusCode == http.StatusOK, nil
-}
-
 // AddVMToTeams adds a VM to multiple teams using the centralized client.
 func AddVMToTeams(ctx context.Context, c *client.CrucibleClient, vmID string, teamIDs []string) error {
 	for _, teamID := range teamIDs {
@@ -97,4 +103,3 @@ func RemoveVMFromTeams(ctx context.Context, c *client.CrucibleClient, vmID strin
 	}
 	return nil
 }
-
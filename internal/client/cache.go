@@ -0,0 +1,103 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package client
+
+import (
+	"context"
+	"sync"
+)
+
+// LookupKind identifies a kind of name or ID lookup cached by the client
+type LookupKind string
+
+const (
+	// LookupPermission maps permission names to IDs
+	LookupPermission LookupKind = "permission"
+
+	// LookupRole maps role names to IDs, for both users and team memberships
+	LookupRole LookupKind = "role"
+
+	// LookupRoleName maps role IDs to names
+	LookupRoleName LookupKind = "role_name"
+)
+
+type lookupKey struct {
+	kind LookupKind
+	key  string
+}
+
+// lookupCall is a lookup in progress that concurrent callers for the same key wait on
+type lookupCall struct {
+	done  chan struct{}
+	value string
+	err   error
+}
+
+// lookupCache caches the results of lookups for the lifetime of the provider
+// instance. Concurrent lookups of the same key share a single API request.
+type lookupCache struct {
+	mutex    sync.Mutex
+	entries  map[lookupKey]string
+	inFlight map[lookupKey]*lookupCall
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{
+		entries:  make(map[lookupKey]string),
+		inFlight: make(map[lookupKey]*lookupCall),
+	}
+}
+
+// Lookup returns the cached result for key, calling fetch on a cache miss.
+// Errors are not cached. If the cache is disabled, fetch is always called.
+func (c *CrucibleClient) Lookup(ctx context.Context, kind LookupKind, key string, fetch func(ctx context.Context) (string, error)) (string, error) {
+	if c.lookups == nil {
+		return fetch(ctx)
+	}
+
+	k := lookupKey{kind: kind, key: key}
+
+	c.lookups.mutex.Lock()
+	if value, ok := c.lookups.entries[k]; ok {
+		c.lookups.mutex.Unlock()
+		return value, nil
+	}
+	if call, ok := c.lookups.inFlight[k]; ok {
+		c.lookups.mutex.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+
+	call := &lookupCall{done: make(chan struct{})}
+	c.lookups.inFlight[k] = call
+	c.lookups.mutex.Unlock()
+
+	call.value, call.err = fetch(ctx)
+
+	c.lookups.mutex.Lock()
+	delete(c.lookups.inFlight, k)
+	if call.err == nil {
+		c.lookups.entries[k] = call.value
+	}
+	c.lookups.mutex.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
+
+// InvalidateLookup removes a cached result, for example after the API reports
+// that a cached ID no longer exists
+func (c *CrucibleClient) InvalidateLookup(kind LookupKind, key string) {
+	if c.lookups == nil {
+		return
+	}
+
+	c.lookups.mutex.Lock()
+	delete(c.lookups.entries, lookupKey{kind: kind, key: key})
+	c.lookups.mutex.Unlock()
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,21 +15,76 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cmu-sei/terraform-provider-crucible/internal/tracing"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"golang.org/x/oauth2"
 )
 
 // ProviderConfig holds all configuration needed for the Crucible provider
 type ProviderConfig struct {
-	Username      string
-	Password      string
-	AuthURL       string
-	TokenURL      string
-	VMApiURL      string
-	PlayerApiURL  string
-	CasterApiURL  string
-	ClientID      string
-	ClientSecret  string
-	ClientScopes  []string
+	Username     string
+	Password     string
+	AuthURL      string
+	TokenURL     string
+	VMApiURL     string
+	PlayerApiURL string
+	CasterApiURL string
+	ClientID     string
+	ClientSecret string
+	ClientScopes []string
+
+	// Optional per-API credentials. Unset fields fall back to the global values above.
+	Player *Credentials
+	VM     *Credentials
+	Caster *Credentials
+
+	// HTTPClient is used for API calls and the OAuth2 token exchange. A default
+	// client is used if nil. If the client has no timeout, requests without a
+	// context deadline time out after DefaultRequestTimeout.
+	HTTPClient *http.Client
+
+	// DisableLookupCache disables caching of permission and role lookups
+	DisableLookupCache bool
+
+	// MaxParallelRequests limits the number of concurrent calls ForEach makes
+	// and the number of API requests in flight within it, including in nested
+	// ForEach calls. DefaultMaxParallelRequests is used if zero.
+	MaxParallelRequests int
+
+	// ConfigUnknown reports that the provider configuration contained values
+	// not known yet at plan time, so the URLs and credentials above may be
+	// incomplete. Resources should not call the APIs while it is set.
+	ConfigUnknown bool
+}
+
+// Credentials holds OAuth2 credentials that override the global provider
+// credentials for a single API
+type Credentials struct {
+	Username     string
+	Password     string
+	ClientID     string
+	ClientSecret string
+	ClientScopes []string
+}
+
+// Service identifies one of the Crucible APIs
+type Service string
+
+const (
+	ServicePlayer Service = "player"
+	ServiceVM     Service = "vm"
+	ServiceCaster Service = "caster"
+)
+
+// serviceToken is a token cache for an API with its own credentials
+type serviceToken struct {
+	token *oauth2.Token
+	mutex sync.RWMutex
 }
 
 // CrucibleClient is a centralized HTTP client for all Crucible API calls
@@ -38,8 +94,22 @@ type CrucibleClient struct {
 	token      *oauth2.Token
 	tokenMutex sync.RWMutex
 	httpClient *http.Client
+
+	// serviceTokens holds a separate token cache for each API configured with
+	// its own credentials; other APIs share the global token above
+	serviceTokens map[Service]*serviceToken
+
+	// lookups caches name and ID lookups; nil if caching is disabled
+	lookups *lookupCache
 }
 
+// Sentinel errors matched by APIError through errors.Is
+var (
+	ErrNotFound  = errors.New("resource not found")
+	ErrConflict  = errors.New("resource conflict")
+	ErrForbidden = errors.New("access forbidden")
+)
+
 // APIError represents a structured error from the Crucible APIs
 type APIError struct {
 	StatusCode int
@@ -55,47 +125,116 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("API returned status %d: %s", e.StatusCode, e.Message)
 }
 
+// Is reports whether the error's status code corresponds to the target sentinel
+// error, so callers can use errors.Is(err, client.ErrNotFound)
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
 // NewClient creates a new CrucibleClient with the given configuration
 func NewClient(config *ProviderConfig) *CrucibleClient {
-	return &CrucibleClient{
-		config: config,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	c := &CrucibleClient{
+		config:        config,
+		httpClient:    httpClient,
+		serviceTokens: make(map[Service]*serviceToken),
 	}
+
+	for _, service := range []Service{ServicePlayer, ServiceVM, ServiceCaster} {
+		if c.serviceCredentials(service) != nil {
+			c.serviceTokens[service] = &serviceToken{}
+		}
+	}
+
+	if !config.DisableLookupCache {
+		c.lookups = newLookupCache()
+	}
+
+	return c
+}
+
+// ConfigKnown reports whether the provider configuration the client was
+// built from was fully known. Resources keep their prior state rather than
+// calling the APIs if it was not.
+func (c *CrucibleClient) ConfigKnown() bool {
+	return !c.config.ConfigUnknown
 }
 
 // GetToken returns a valid OAuth2 access token, using cached token if available
 // and automatically refreshing if expired
 func (c *CrucibleClient) GetToken(ctx context.Context) (string, error) {
+	token, err := c.getCachedToken(ctx, &c.tokenMutex, &c.token, c.globalCredentials())
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// GetServiceToken returns a valid OAuth2 access token for the given API. APIs
+// without their own credentials share the global token.
+func (c *CrucibleClient) GetServiceToken(ctx context.Context, service Service) (string, error) {
+	token, err := c.GetServiceOAuthToken(ctx, service)
+	if err != nil {
+		return "", err
+	}
+	return token.AccessToken, nil
+}
+
+// GetServiceOAuthToken returns the valid OAuth2 token used for the given API,
+// including when it expires. APIs without their own credentials, and the empty
+// Service, share the global token. The token must not be modified.
+func (c *CrucibleClient) GetServiceOAuthToken(ctx context.Context, service Service) (*oauth2.Token, error) {
+	cache, ok := c.serviceTokens[service]
+	if !ok {
+		return c.getCachedToken(ctx, &c.tokenMutex, &c.token, c.globalCredentials())
+	}
+
+	return c.getCachedToken(ctx, &cache.mutex, &cache.token, c.credentialsFor(service))
+}
+
+// getCachedToken returns the token held in cached, fetching a new one with
+// creds if it is missing or expired
+func (c *CrucibleClient) getCachedToken(ctx context.Context, mutex *sync.RWMutex, cached **oauth2.Token, creds Credentials) (*oauth2.Token, error) {
 	// Fast path: check if we have a valid cached token
-	c.tokenMutex.RLock()
-	if c.token != nil && c.token.Valid() {
-		token := c.token.AccessToken
-		c.tokenMutex.RUnlock()
+	mutex.RLock()
+	if *cached != nil && (*cached).Valid() {
+		token := *cached
+		mutex.RUnlock()
 		return token, nil
 	}
-	c.tokenMutex.RUnlock()
+	mutex.RUnlock()
 
 	// Slow path: acquire write lock and fetch new token
-	c.tokenMutex.Lock()
-	defer c.tokenMutex.Unlock()
+	mutex.Lock()
+	defer mutex.Unlock()
 
 	// Double-check in case another goroutine already refreshed
-	if c.token != nil && c.token.Valid() {
-		return c.token.AccessToken, nil
+	if *cached != nil && (*cached).Valid() {
+		return *cached, nil
 	}
 
 	// Parse scopes - handle empty string or nil
-	scopes := c.config.ClientScopes
+	scopes := creds.ClientScopes
 	if len(scopes) == 1 && scopes[0] == "" {
 		scopes = nil
 	}
 
 	// Create OAuth2 config
 	oauthConfig := &oauth2.Config{
-		ClientID:     c.config.ClientID,
-		ClientSecret: c.config.ClientSecret,
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
 		Scopes:       scopes,
 		Endpoint: oauth2.Endpoint{
 			AuthURL:  c.config.AuthURL,
@@ -103,51 +242,138 @@ func (c *CrucibleClient) GetToken(ctx context.Context) (string, error) {
 		},
 	}
 
-	// Fetch token using password credentials grant
-	token, err := oauthConfig.PasswordCredentialsToken(ctx, c.config.Username, c.config.Password)
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
+
+	// Fetch token using password credentials grant, through the same HTTP client as API calls
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.httpClient)
+	token, err := oauthConfig.PasswordCredentialsToken(ctx, creds.Username, creds.Password)
 	if err != nil {
-		return "", fmt.Errorf("failed to obtain OAuth2 token: %w", err)
+		return nil, fmt.Errorf("failed to obtain OAuth2 token: %w", err)
 	}
 
-	c.token = token
-	return token.AccessToken, nil
+	*cached = token
+	return token, nil
+}
+
+// invalidateToken discards the cached token used for the given API
+func (c *CrucibleClient) invalidateToken(service Service) {
+	if cache, ok := c.serviceTokens[service]; ok {
+		cache.mutex.Lock()
+		cache.token = nil
+		cache.mutex.Unlock()
+		return
+	}
+
+	c.tokenMutex.Lock()
+	c.token = nil
+	c.tokenMutex.Unlock()
+}
+
+// globalCredentials returns the provider-wide OAuth2 credentials
+func (c *CrucibleClient) globalCredentials() Credentials {
+	return Credentials{
+		Username:     c.config.Username,
+		Password:     c.config.Password,
+		ClientID:     c.config.ClientID,
+		ClientSecret: c.config.ClientSecret,
+		ClientScopes: c.config.ClientScopes,
+	}
+}
+
+// serviceCredentials returns the credential overrides configured for an API, or nil
+func (c *CrucibleClient) serviceCredentials(service Service) *Credentials {
+	switch service {
+	case ServicePlayer:
+		return c.config.Player
+	case ServiceVM:
+		return c.config.VM
+	case ServiceCaster:
+		return c.config.Caster
+	}
+	return nil
+}
+
+// credentialsFor returns the credentials used for an API, filling any unset
+// override fields from the global credentials
+func (c *CrucibleClient) credentialsFor(service Service) Credentials {
+	creds := c.globalCredentials()
+
+	override := c.serviceCredentials(service)
+	if override == nil {
+		return creds
+	}
+
+	if override.Username != "" {
+		creds.Username = override.Username
+	}
+	if override.Password != "" {
+		creds.Password = override.Password
+	}
+	if override.ClientID != "" {
+		creds.ClientID = override.ClientID
+	}
+	if override.ClientSecret != "" {
+		creds.ClientSecret = override.ClientSecret
+	}
+	if len(override.ClientScopes) > 0 {
+		creds.ClientScopes = override.ClientScopes
+	}
+
+	return creds
+}
+
+// serviceForURL returns the API that the given request URL belongs to, matching
+// the longest configured base URL. An empty Service is returned for unknown URLs.
+func (c *CrucibleClient) serviceForURL(url string) Service {
+	var match Service
+	longest := 0
+
+	bases := map[Service]string{
+		ServicePlayer: c.config.PlayerApiURL,
+		ServiceVM:     c.config.VMApiURL,
+		ServiceCaster: c.config.CasterApiURL,
+	}
+	for service, base := range bases {
+		if base == "" {
+			continue
+		}
+		prefix := NormalizeAPIURL(base)
+		if strings.HasPrefix(url, prefix) && len(prefix) > longest {
+			match = service
+			longest = len(prefix)
+		}
+	}
+
+	return match
 }
 
 // DoRequest performs an HTTP request with automatic authentication
 // It handles token injection, retries on auth failures, and returns the response
 func (c *CrucibleClient) DoRequest(ctx context.Context, method, url string, body interface{}) (*http.Response, error) {
+	ctx = c.withLogging(ctx)
+
 	// Marshal body if provided
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		bodyReader = bytes.NewBuffer(jsonBody)
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-	}
-
-	// Get auth token
-	token, err := c.GetToken(ctx)
+	// Get auth token for the API this request targets
+	service := c.serviceForURL(url)
+	token, err := c.GetServiceToken(ctx, service)
 	if err != nil {
 		return nil, err
 	}
 
-	// Set headers
-	req.Header.Set("Authorization", "Bearer "+token)
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-
 	// Execute request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(ctx, method, url, jsonBody, token)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+		return nil, err
 	}
 
 	// If we get 401, token might have expired - try refreshing once
@@ -155,34 +381,121 @@ func (c *CrucibleClient) DoRequest(ctx context.Context, method, url string, body
 		resp.Body.Close()
 
 		// Invalidate cached token and get a fresh one
-		c.tokenMutex.Lock()
-		c.token = nil
-		c.tokenMutex.Unlock()
+		c.invalidateToken(service)
 
-		token, err = c.GetToken(ctx)
+		token, err = c.GetServiceToken(ctx, service)
 		if err != nil {
 			return nil, err
 		}
 
-		// Recreate request with new token
-		if body != nil {
-			jsonBody, _ := json.Marshal(body)
-			bodyReader = bytes.NewBuffer(jsonBody)
-		}
-		req, err = http.NewRequestWithContext(ctx, method, url, bodyReader)
+		// Retry request with new token
+		tflog.SubsystemDebug(ctx, logSubsystem, "Retrying API request with refreshed token", map[string]interface{}{
+			"method": method,
+			"url":    url,
+		})
+		resp, err = c.send(ctx, method, url, jsonBody, token)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create retry HTTP request: %w", err)
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
+			return nil, fmt.Errorf("retry failed: %w", err)
 		}
+	}
 
-		// Retry request
-		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("HTTP retry request failed: %w", err)
-		}
+	return resp, nil
+}
+
+// send builds and executes a single authenticated request, logging the request
+// and response. Headers are logged at DEBUG and bodies at TRACE, with secrets redacted.
+func (c *CrucibleClient) send(ctx context.Context, method, url string, jsonBody []byte, token string) (*http.Response, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
+		bodyReader = bytes.NewReader(jsonBody)
+	}
+
+	ctx, cancel := c.withDefaultTimeout(ctx)
+	defer cancel()
+
+	ctx, span := tracing.Start(ctx, "HTTP "+method,
+		attribute.String("http.request.method", method),
+		attribute.String("url.full", url),
+	)
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// Propagate the trace context so API logs can be correlated with this span
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	// Set headers
+	correlationID := uuid.NewString()
+	span.SetAttributes(attribute.String("crucible.correlation_id", correlationID))
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(correlationIDHeader, correlationID)
+	if jsonBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	fields := map[string]interface{}{
+		"method":         method,
+		"url":            url,
+		"correlation_id": correlationID,
+	}
+	tflog.SubsystemDebug(ctx, logSubsystem, "Sending API request", mergeFields(fields, map[string]interface{}{
+		"request_headers": redactHeaders(req.Header),
+	}))
+	if jsonBody != nil {
+		tflog.SubsystemTrace(ctx, logSubsystem, "API request body", mergeFields(fields, map[string]interface{}{
+			"request_body": redactBody(jsonBody),
+		}))
+	}
+
+	release, err := acquireRequestSlot(ctx)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	// Execute request
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		release()
+		tflog.SubsystemDebug(ctx, logSubsystem, "API request failed", mergeFields(fields, map[string]interface{}{
+			"duration_ms": duration.Milliseconds(),
+			"error":       err.Error(),
+		}))
+		tracing.End(span, err)
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	span.End()
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received API response", mergeFields(fields, map[string]interface{}{
+		"status":      resp.StatusCode,
+		"duration_ms": duration.Milliseconds(),
+	}))
+
+	// Buffer the response body so it can be logged and still returned to the caller
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	release()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	if len(respBody) > 0 {
+		tflog.SubsystemTrace(ctx, logSubsystem, "API response body", mergeFields(fields, map[string]interface{}{
+			"status":        resp.StatusCode,
+			"response_body": redactBody(respBody),
+		}))
 	}
 
 	return resp, nil
@@ -232,22 +545,22 @@ func (c *CrucibleClient) HandleAPIError(resp *http.Response) *APIError {
 
 // GetPlayerAPIURL returns the normalized Player API base URL
 func (c *CrucibleClient) GetPlayerAPIURL() string {
-	return normalizeAPIURL(c.config.PlayerApiURL)
+	return NormalizeAPIURL(c.config.PlayerApiURL)
 }
 
 // GetVMAPIURL returns the normalized VM API base URL
 func (c *CrucibleClient) GetVMAPIURL() string {
-	return normalizeAPIURL(c.config.VMApiURL)
+	return NormalizeAPIURL(c.config.VMApiURL)
 }
 
 // GetCasterAPIURL returns the normalized Caster API base URL
 func (c *CrucibleClient) GetCasterAPIURL() string {
-	return normalizeAPIURL(c.config.CasterApiURL)
+	return NormalizeAPIURL(c.config.CasterApiURL)
 }
 
-// normalizeAPIURL ensures consistent URL formatting
+// NormalizeAPIURL ensures consistent URL formatting
 // Strips trailing "/" and "/api", then appends "/api/"
-func normalizeAPIURL(url string) string {
+func NormalizeAPIURL(url string) string {
 	url = strings.TrimSuffix(url, "/")
 	url = strings.TrimSuffix(url, "/api")
 	return url + "/api/"
@@ -4,14 +4,26 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 	"golang.org/x/oauth2"
 )
 
@@ -21,9 +33,10 @@ func TestGetToken_Caching(t *testing.T) {
 
 	// Mock OAuth2 token server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
 		callCount++
 		token := map[string]interface{}{
-			"access_token": "test-token-" + string(rune(callCount)),
+			"access_token": fmt.Sprintf("test-token-%d", callCount),
 			"token_type":   "Bearer",
 			"expires_in":   3600,
 		}
@@ -74,9 +87,10 @@ func TestGetToken_Expiry(t *testing.T) {
 
 	// Mock OAuth2 token server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
 		callCount++
 		token := map[string]interface{}{
-			"access_token": "refreshed-token-" + string(rune(callCount)),
+			"access_token": fmt.Sprintf("refreshed-token-%d", callCount),
 			"token_type":   "Bearer",
 			"expires_in":   1, // Expire in 1 second
 		}
@@ -225,6 +239,7 @@ func TestHandleAPIError_PlainText(t *testing.T) {
 func TestDoGet(t *testing.T) {
 	// Mock OAuth2 token server
 	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"access_token": "test-token",
 			"token_type":   "Bearer",
@@ -278,6 +293,7 @@ func TestDoGet(t *testing.T) {
 func TestDoPost(t *testing.T) {
 	// Mock OAuth2 token server
 	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(map[string]interface{}{
 			"access_token": "test-token",
 			"token_type":   "Bearer",
@@ -352,9 +368,11 @@ func TestDoRequest_401Retry(t *testing.T) {
 
 	// Mock OAuth2 token server
 	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
 		tokenCallCount++
+		// Number tokens after the pre-cached token-1
 		json.NewEncoder(w).Encode(map[string]interface{}{
-			"access_token": "token-" + string(rune(tokenCallCount)),
+			"access_token": fmt.Sprintf("token-%d", tokenCallCount+1),
 			"token_type":   "Bearer",
 			"expires_in":   3600,
 		})
@@ -441,9 +459,9 @@ func TestNormalizeAPIURL(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		result := normalizeAPIURL(test.input)
+		result := NormalizeAPIURL(test.input)
 		if result != test.expected {
-			t.Errorf("normalizeAPIURL(%q) = %q, want %q", test.input, result, test.expected)
+			t.Errorf("NormalizeAPIURL(%q) = %q, want %q", test.input, result, test.expected)
 		}
 	}
 }
@@ -517,3 +535,646 @@ func TestHandleAPIError_MultipleErrorFormats(t *testing.T) {
 		})
 	}
 }
+
+// TestAPIError_Is verifies that API errors match sentinel errors by status code, including when wrapped
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		target     error
+		expected   bool
+	}{
+		{http.StatusNotFound, ErrNotFound, true},
+		{http.StatusConflict, ErrConflict, true},
+		{http.StatusForbidden, ErrForbidden, true},
+		{http.StatusNotFound, ErrConflict, false},
+		{http.StatusInternalServerError, ErrNotFound, false},
+	}
+
+	for _, test := range tests {
+		err := fmt.Errorf("failed to read view: %w", &APIError{StatusCode: test.statusCode, Message: http.StatusText(test.statusCode)})
+		if errors.Is(err, test.target) != test.expected {
+			t.Errorf("errors.Is(status %d, %v) = %v, expected %v", test.statusCode, test.target, !test.expected, test.expected)
+		}
+	}
+}
+
+// TestDoRequest_ServiceCredentials verifies that APIs with their own credentials use a separate token cache
+func TestDoRequest_ServiceCredentials(t *testing.T) {
+	tokenCalls := map[string]int{}
+
+	// Mock OAuth2 token server that issues a token per username
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		username := r.PostForm.Get("username")
+		tokenCalls[username]++
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + username,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	// Mock API server that expects the VM API to use its own token
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expected := "Bearer token-test-user"
+		if strings.HasPrefix(r.URL.Path, "/vm/") {
+			expected = "Bearer token-vm-user"
+		}
+
+		if r.Header.Get("Authorization") != expected {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"result": "success"})
+	}))
+	defer apiServer.Close()
+
+	config := &ProviderConfig{
+		Username:     "test-user",
+		Password:     "test-pass",
+		TokenURL:     tokenServer.URL,
+		ClientID:     "test-client",
+		PlayerApiURL: apiServer.URL + "/player",
+		VMApiURL:     apiServer.URL + "/vm",
+		VM: &Credentials{
+			Username:     "vm-user",
+			ClientScopes: []string{"vm-api"},
+		},
+	}
+
+	client := NewClient(config)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		var result map[string]interface{}
+		if err := client.DoGet(ctx, client.GetPlayerAPIURL()+"views", &result); err != nil {
+			t.Fatalf("Player API request failed: %v", err)
+		}
+		if err := client.DoGet(ctx, client.GetVMAPIURL()+"vms", &result); err != nil {
+			t.Fatalf("VM API request failed: %v", err)
+		}
+	}
+
+	// Each set of credentials should have fetched exactly one token
+	if tokenCalls["test-user"] != 1 || tokenCalls["vm-user"] != 1 {
+		t.Errorf("Expected one token per credential set, got %v", tokenCalls)
+	}
+
+	// Unset override fields should fall back to the global credentials
+	creds := client.credentialsFor(ServiceVM)
+	if creds.ClientID != "test-client" || creds.Password != "test-pass" {
+		t.Errorf("Expected VM credentials to inherit global client ID and password, got %+v", creds)
+	}
+}
+
+// TestGetServiceOAuthToken verifies that the OAuth2 token of each API is shared with API calls and reports its expiry
+func TestGetServiceOAuthToken(t *testing.T) {
+	tokenCalls := 0
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		tokenCalls++
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + r.PostForm.Get("username"),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	client := NewClient(&ProviderConfig{
+		Username: "test-user",
+		Password: "test-pass",
+		TokenURL: tokenServer.URL,
+		ClientID: "test-client",
+		Caster:   &Credentials{Username: "caster-user"},
+	})
+	ctx := context.Background()
+
+	token, err := client.GetServiceOAuthToken(ctx, "")
+	if err != nil {
+		t.Fatalf("GetServiceOAuthToken failed: %v", err)
+	}
+	if token.AccessToken != "token-test-user" {
+		t.Errorf("Expected the global token, got %s", token.AccessToken)
+	}
+	if remaining := time.Until(token.Expiry); remaining < 59*time.Minute || remaining > time.Hour {
+		t.Errorf("Expected the token to expire in an hour, got %s", remaining)
+	}
+
+	// The VM API has no credentials of its own, so it shares the cached global token
+	if accessToken, err := client.GetServiceToken(ctx, ServiceVM); err != nil || accessToken != token.AccessToken {
+		t.Errorf("Expected the VM API to share the global token, got %s (%v)", accessToken, err)
+	}
+
+	token, err = client.GetServiceOAuthToken(ctx, ServiceCaster)
+	if err != nil || token.AccessToken != "token-caster-user" {
+		t.Errorf("Expected the Caster API token, got %v (%v)", token, err)
+	}
+	if tokenCalls != 2 {
+		t.Errorf("Expected 2 token requests, got %d", tokenCalls)
+	}
+}
+
+// TestNewHTTPClient_CustomCA verifies that a custom CA is trusted for both the token exchange and API calls
+func TestNewHTTPClient_CustomCA(t *testing.T) {
+	// Mock TLS OAuth2 token server
+	tokenServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	// Mock TLS API server
+	apiServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"result": "success"})
+	}))
+	defer apiServer.Close()
+
+	// Both test servers share the same self-signed certificate
+	caPEM := string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: apiServer.Certificate().Raw,
+	}))
+
+	config := &ProviderConfig{
+		Username: "test-user",
+		Password: "test-pass",
+		TokenURL: tokenServer.URL,
+		ClientID: "test-client",
+	}
+	ctx := context.Background()
+
+	// Without the CA, the token exchange should fail certificate verification
+	var result map[string]interface{}
+	if err := NewClient(config).DoGet(ctx, apiServer.URL, &result); err == nil {
+		t.Fatal("Expected request to fail without trusting the test CA")
+	}
+
+	httpClient, err := NewHTTPClient(TransportConfig{CACertPEM: caPEM, RequestTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatalf("NewHTTPClient failed: %v", err)
+	}
+	if httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected 5s timeout, got %s", httpClient.Timeout)
+	}
+
+	config.HTTPClient = httpClient
+	if err := NewClient(config).DoGet(ctx, apiServer.URL, &result); err != nil {
+		t.Fatalf("DoGet with custom CA failed: %v", err)
+	}
+	if result["result"] != "success" {
+		t.Errorf("Expected successful response, got %v", result)
+	}
+}
+
+// TestNewHTTPClient_InvalidConfig verifies that invalid transport settings are rejected
+func TestNewHTTPClient_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config TransportConfig
+	}{
+		{"invalid CA PEM", TransportConfig{CACertPEM: "not a certificate"}},
+		{"missing CA file", TransportConfig{CACertFile: "/nonexistent/ca.pem"}},
+		{"client cert without key", TransportConfig{ClientCert: "-----BEGIN CERTIFICATE-----"}},
+		{"invalid proxy URL", TransportConfig{HTTPProxy: "http://[::1"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewHTTPClient(test.config); err == nil {
+				t.Errorf("Expected error for %s", test.name)
+			}
+		})
+	}
+}
+
+// TestDoRequest_Logging verifies that requests are logged with secrets redacted
+func TestDoRequest_Logging(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "secret-access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	var correlationID string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID = r.Header.Get("X-Correlation-ID")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": "vm-1",
+			"consoleConnectionInfo": map[string]string{
+				"hostname": "vm1.example.local",
+				"password": "console-password",
+			},
+		})
+	}))
+	defer apiServer.Close()
+
+	config := &ProviderConfig{
+		Username:     "test-user",
+		Password:     "user-password",
+		TokenURL:     tokenServer.URL,
+		ClientID:     "test-client",
+		ClientSecret: "client-secret-value",
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(config)
+	requestBody := map[string]interface{}{
+		"name": "vm-1",
+		"consoleConnectionInfo": map[string]string{
+			"password": "console-password",
+		},
+	}
+
+	var result map[string]interface{}
+	if err := client.DoPost(ctx, apiServer.URL, requestBody, &result); err != nil {
+		t.Fatalf("DoPost failed: %v", err)
+	}
+
+	// The response body must still be readable after being logged
+	if result["id"] != "vm-1" {
+		t.Errorf("Expected id=vm-1, got %v", result["id"])
+	}
+
+	logs := output.String()
+	for _, secret := range []string{"secret-access-token", "console-password", "user-password", "client-secret-value"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("Expected %q to be redacted from logs", secret)
+		}
+	}
+
+	if correlationID == "" || !strings.Contains(logs, correlationID) {
+		t.Errorf("Expected correlation ID %q to be sent and logged", correlationID)
+	}
+	if !strings.Contains(logs, "vm1.example.local") {
+		t.Error("Expected non-sensitive response body fields to be logged")
+	}
+}
+
+// TestDoRequest_TracePropagation verifies that API requests carry W3C trace context headers
+func TestDoRequest_TracePropagation(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	}()
+
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	var traceparent string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		json.NewEncoder(w).Encode(map[string]string{"result": "success"})
+	}))
+	defer apiServer.Close()
+
+	client := NewClient(&ProviderConfig{
+		Username:     "test-user",
+		Password:     "test-pass",
+		TokenURL:     tokenServer.URL,
+		ClientID:     "test-client",
+		PlayerApiURL: apiServer.URL,
+	})
+
+	var result map[string]interface{}
+	if err := client.DoGet(context.Background(), client.GetPlayerAPIURL()+"views", &result); err != nil {
+		t.Fatalf("DoGet failed: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+
+	expected := "00-" + spans[0].SpanContext.TraceID().String() + "-" + spans[0].SpanContext.SpanID().String() + "-01"
+	if traceparent != expected {
+		t.Errorf("Expected traceparent %q, got %q", expected, traceparent)
+	}
+}
+
+// TestDoList_Pagination verifies that DoList follows Link and X-Pagination headers, requests further pages
+// while pages without headers are full, and stops on ErrStopPaging
+func TestDoList_Pagination(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("pageNumber")
+
+		switch r.URL.Path {
+		case "/api/link":
+			if page == "1" {
+				w.Header().Set("Link", `</api/link?pageNumber=2&pageSize=2>; rel="next"`)
+				json.NewEncoder(w).Encode([]string{"a", "b"})
+			} else {
+				json.NewEncoder(w).Encode([]string{"c"})
+			}
+		case "/api/header":
+			w.Header().Set("X-Pagination", fmt.Sprintf(`{"currentPage":%s,"totalPages":3}`, page))
+			json.NewEncoder(w).Encode([]string{"item-" + page})
+		case "/api/unpaged":
+			json.NewEncoder(w).Encode([]string{"a", "b", "c"})
+		case "/api/sized":
+			// Honours the paging parameters but sends no paging headers
+			items := []string{"a", "b", "c", "d", "e"}
+			number, _ := strconv.Atoi(page)
+			size, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+			start := min((number-1)*size, len(items))
+			json.NewEncoder(w).Encode(items[start:min(start+size, len(items))])
+		case "/api/ignored":
+			// Ignores the paging parameters and happens to fill a page
+			json.NewEncoder(w).Encode([]string{"a", "b"})
+		}
+	}))
+	defer apiServer.Close()
+
+	client := NewClient(&ProviderConfig{
+		Username:     "test-user",
+		Password:     "test-pass",
+		TokenURL:     tokenServer.URL,
+		ClientID:     "test-client",
+		PlayerApiURL: apiServer.URL,
+	})
+	ctx := context.Background()
+
+	collect := func(path string, limit int) []string {
+		var items []string
+		err := client.DoList(ctx, client.GetPlayerAPIURL()+path, func(page json.RawMessage) error {
+			var pageItems []string
+			if err := json.Unmarshal(page, &pageItems); err != nil {
+				return err
+			}
+			items = append(items, pageItems...)
+			if limit > 0 && len(items) >= limit {
+				return ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("DoList(%s) failed: %v", path, err)
+		}
+		return items
+	}
+
+	tests := []struct {
+		path     string
+		limit    int
+		expected string
+	}{
+		{"link", 0, "a,b,c"},
+		{"header", 0, "item-1,item-2,item-3"},
+		{"header", 2, "item-1,item-2"},
+		{"unpaged", 0, "a,b,c"},
+		{"sized?pageSize=2", 0, "a,b,c,d,e"},
+		{"sized?pageSize=5", 0, "a,b,c,d,e"},
+		{"ignored?pageSize=2", 0, "a,b"},
+	}
+
+	for _, test := range tests {
+		if got := strings.Join(collect(test.path, test.limit), ","); got != test.expected {
+			t.Errorf("DoList(%s, limit %d) = %q, expected %q", test.path, test.limit, got, test.expected)
+		}
+	}
+}
+
+// TestLookup_Caching verifies that lookups are cached, shared between concurrent callers and can be invalidated or disabled
+func TestLookup_Caching(t *testing.T) {
+	ctx := context.Background()
+
+	var mutex sync.Mutex
+	fetches := 0
+	fetch := func(ctx context.Context) (string, error) {
+		mutex.Lock()
+		fetches++
+		mutex.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return "perm-id", nil
+	}
+
+	client := NewClient(&ProviderConfig{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if id, err := client.Lookup(ctx, LookupPermission, "ViewAdmin", fetch); err != nil || id != "perm-id" {
+				t.Errorf("Lookup returned %q, %v", id, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if fetches != 1 {
+		t.Errorf("Expected 1 fetch for concurrent lookups, got %d", fetches)
+	}
+
+	// Errors are not cached
+	if _, err := client.Lookup(ctx, LookupRole, "Missing", func(context.Context) (string, error) {
+		return "", ErrNotFound
+	}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	client.InvalidateLookup(LookupPermission, "ViewAdmin")
+	client.Lookup(ctx, LookupPermission, "ViewAdmin", fetch)
+	if fetches != 2 {
+		t.Errorf("Expected a new fetch after invalidation, got %d fetches", fetches)
+	}
+
+	// A client with caching disabled fetches every time
+	uncached := NewClient(&ProviderConfig{DisableLookupCache: true})
+	uncached.Lookup(ctx, LookupPermission, "ViewAdmin", fetch)
+	uncached.Lookup(ctx, LookupPermission, "ViewAdmin", fetch)
+	if fetches != 4 {
+		t.Errorf("Expected 4 fetches with caching disabled, got %d", fetches)
+	}
+}
+
+// TestForEach verifies that ForEach bounds concurrency, runs every call and joins errors in index order
+func TestForEach(t *testing.T) {
+	client := NewClient(&ProviderConfig{MaxParallelRequests: 2})
+
+	var mutex sync.Mutex
+	running, maxRunning, calls := 0, 0, 0
+
+	err := client.ForEach(context.Background(), 6, func(ctx context.Context, i int) error {
+		mutex.Lock()
+		running++
+		calls++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		running--
+		mutex.Unlock()
+
+		if i%2 == 1 {
+			return fmt.Errorf("item %d failed", i)
+		}
+		return nil
+	})
+
+	if calls != 6 {
+		t.Errorf("Expected 6 calls, got %d", calls)
+	}
+	if maxRunning > 2 {
+		t.Errorf("Expected at most 2 concurrent calls, got %d", maxRunning)
+	}
+	if err == nil || err.Error() != "item 1 failed\nitem 3 failed\nitem 5 failed" {
+		t.Errorf("Expected joined errors in index order, got %v", err)
+	}
+}
+
+// TestForEach_NestedRequestLimit verifies that nested ForEach calls share one limit on API requests in flight
+func TestForEach_NestedRequestLimit(t *testing.T) {
+	var mutex sync.Mutex
+	inFlight, maxInFlight, requests := 0, 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/token" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "test-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+
+		mutex.Lock()
+		inFlight++
+		requests++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mutex.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mutex.Lock()
+		inFlight--
+		mutex.Unlock()
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	client := NewClient(&ProviderConfig{
+		Username:            "test-user",
+		Password:            "test-pass",
+		TokenURL:            server.URL + "/token",
+		ClientID:            "test-client",
+		PlayerApiURL:        server.URL,
+		MaxParallelRequests: 2,
+	})
+
+	// Like view creation: each team makes a request, then two nested fan-outs
+	err := client.ForEach(context.Background(), 3, func(ctx context.Context, i int) error {
+		if err := client.DoPost(ctx, client.GetPlayerAPIURL()+"teams", nil, nil); err != nil {
+			return err
+		}
+		usersErr := client.ForEach(ctx, 3, func(ctx context.Context, j int) error {
+			return client.DoPost(ctx, client.GetPlayerAPIURL()+"users", nil, nil)
+		})
+		instancesErr := client.ForEach(ctx, 3, func(ctx context.Context, j int) error {
+			return client.DoPost(ctx, client.GetPlayerAPIURL()+"instances", nil, nil)
+		})
+		return errors.Join(usersErr, instancesErr)
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests != 21 {
+		t.Errorf("Expected 21 requests, got %d", requests)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+// TestDoRequest_ContextDeadline verifies that an operation deadline on the context bounds API requests
+func TestDoRequest_ContextDeadline(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "test-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer tokenServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer apiServer.Close()
+
+	client := NewClient(&ProviderConfig{
+		Username:     "test-user",
+		Password:     "test-pass",
+		TokenURL:     tokenServer.URL,
+		ClientID:     "test-client",
+		PlayerApiURL: apiServer.URL,
+	})
+
+	// Fetch the token first so only the API request is subject to the deadline
+	if _, err := client.GetToken(context.Background()); err != nil {
+		t.Fatalf("GetToken failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	var result map[string]interface{}
+	err := client.DoGet(ctx, client.GetPlayerAPIURL()+"views", &result)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Request was not cancelled at the deadline, took %s", elapsed)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// logSubsystem is the tflog subsystem used for API request logging. Its level
+	// can be set independently with the TF_LOG_PROVIDER_CRUCIBLE_API environment variable.
+	logSubsystem = "crucible_api"
+
+	// correlationIDHeader is sent with every request so provider logs can be
+	// matched with API logs
+	correlationIDHeader = "X-Correlation-ID"
+
+	redactedValue = "***"
+)
+
+// sensitiveKeys are matched case-insensitively against JSON field and header
+// names whose values must never be logged
+var sensitiveKeys = []string{
+	"authorization",
+	"password",
+	"secret",
+	"access_token",
+	"accesstoken",
+	"refresh_token",
+	"refreshtoken",
+}
+
+// withLogging returns a context with the API logging subsystem configured to
+// mask credentials
+func (c *CrucibleClient) withLogging(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_CRUCIBLE", "API"))
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, "authorization", "password", "client_secret")
+
+	// Mask the configured secrets wherever they might appear in a logged value
+	var secrets []string
+	for _, creds := range []*Credentials{c.serviceCredentials(ServicePlayer), c.serviceCredentials(ServiceVM), c.serviceCredentials(ServiceCaster)} {
+		if creds != nil {
+			secrets = append(secrets, creds.Password, creds.ClientSecret)
+		}
+	}
+	secrets = append(secrets, c.config.Password, c.config.ClientSecret)
+
+	var nonEmpty []string
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+	if len(nonEmpty) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, nonEmpty...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, nonEmpty...)
+	}
+
+	return ctx
+}
+
+// isSensitiveKey reports whether a field or header name holds a secret
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders returns a copy of the headers suitable for logging
+func redactHeaders(headers http.Header) map[string]string {
+	redacted := make(map[string]string, len(headers))
+	for key := range headers {
+		if isSensitiveKey(key) {
+			redacted[key] = redactedValue
+		} else {
+			redacted[key] = headers.Get(key)
+		}
+	}
+	return redacted
+}
+
+// redactBody returns a JSON body with the values of sensitive fields replaced.
+// Bodies that are not valid JSON are returned unchanged.
+func redactBody(body []byte) string {
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+// redactValue walks a decoded JSON value, replacing sensitive fields
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveKey(key) && field != nil {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactValue(field)
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactValue(v[i])
+		}
+	}
+	return value
+}
+
+// mergeFields returns a new map containing the fields of both maps
+func mergeFields(base, extra map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(extra))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,189 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPageSize is the number of items requested per page by DoList
+	DefaultPageSize = 100
+
+	pageNumberParam  = "pageNumber"
+	pageSizeParam    = "pageSize"
+	paginationHeader = "X-Pagination"
+)
+
+// ErrStopPaging can be returned by a DoList page handler to stop fetching
+// further pages without DoList returning an error
+var ErrStopPaging = errors.New("stop paging")
+
+// paginationMetadata is the JSON paging summary some APIs return in the X-Pagination header
+type paginationMetadata struct {
+	CurrentPage int `json:"currentPage"`
+	TotalPages  int `json:"totalPages"`
+}
+
+// DoList performs a GET request against a list endpoint and passes each page of
+// results, a JSON array, to handlePage. Further pages are requested while the
+// response has a Link header with rel="next" or an X-Pagination header
+// reporting more pages. Without either header, further pages are requested
+// while a page is full, and a page repeating the previous one, from an API
+// that ignores the paging parameters, ends the collection.
+func (c *CrucibleClient) DoList(ctx context.Context, listURL string, handlePage func(page json.RawMessage) error) error {
+	pageURL, err := withPageParams(listURL, 1)
+	if err != nil {
+		return err
+	}
+
+	var previous []byte
+	for pageNumber := 1; pageURL != ""; pageNumber++ {
+		resp, err := c.DoRequest(ctx, "GET", pageURL, nil)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return c.HandleAPIError(resp)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		if previous != nil && bytes.Equal(body, previous) {
+			return nil
+		}
+		previous = body
+
+		if err := handlePage(body); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+
+		pageURL, err = nextPageURL(resp, body, listURL, pageURL, pageNumber)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextPageURL returns the URL of the page after the current one, or an empty
+// string if the current page is the last
+func nextPageURL(resp *http.Response, body []byte, listURL, currentURL string, pageNumber int) (string, error) {
+	if next := linkNext(resp.Header.Values("Link")); next != "" {
+		base, err := url.Parse(currentURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid page URL: %w", err)
+		}
+		nextURL, err := base.Parse(next)
+		if err != nil {
+			return "", fmt.Errorf("invalid next page link %q: %w", next, err)
+		}
+		if nextURL.String() == currentURL {
+			return "", nil
+		}
+		return nextURL.String(), nil
+	}
+
+	if header := resp.Header.Get(paginationHeader); header != "" {
+		var metadata paginationMetadata
+		if err := json.Unmarshal([]byte(header), &metadata); err != nil {
+			return "", fmt.Errorf("invalid %s header: %w", paginationHeader, err)
+		}
+
+		current := metadata.CurrentPage
+		if current == 0 {
+			current = pageNumber
+		}
+		if current < metadata.TotalPages {
+			return withPageParams(listURL, current+1)
+		}
+		return "", nil
+	}
+
+	// Without paging headers, a full page may not be the last
+	if isFullPage(body, currentURL) {
+		return withPageParams(listURL, pageNumber+1)
+	}
+
+	return "", nil
+}
+
+// isFullPage reports whether body, a page of results requested with
+// pageURL, holds as many items as the page size pageURL asks for
+func isFullPage(body []byte, pageURL string) bool {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+	pageSize, err := strconv.Atoi(parsed.Query().Get(pageSizeParam))
+	if err != nil {
+		return false
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(body, &items); err != nil {
+		return false
+	}
+	return len(items) == pageSize
+}
+
+// linkNext returns the target of the rel="next" entry in RFC 8288 Link headers
+func linkNext(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+				if !found || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.TrimSuffix(strings.TrimPrefix(target, "<"), ">")
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// withPageParams sets the page number and page size query parameters on a list URL
+func withPageParams(listURL string, pageNumber int) (string, error) {
+	parsed, err := url.Parse(listURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid list URL: %w", err)
+	}
+
+	query := parsed.Query()
+	query.Set(pageNumberParam, strconv.Itoa(pageNumber))
+	if query.Get(pageSizeParam) == "" {
+		query.Set(pageSizeParam, strconv.Itoa(DefaultPageSize))
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String(), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// DefaultMaxParallelRequests is used when no request concurrency limit is configured
+const DefaultMaxParallelRequests = 4
+
+// MaxParallelRequests returns the maximum number of calls ForEach runs
+// concurrently
+func (c *CrucibleClient) MaxParallelRequests() int {
+	if c.config.MaxParallelRequests > 0 {
+		return c.config.MaxParallelRequests
+	}
+	return DefaultMaxParallelRequests
+}
+
+// requestLimiterKey is the context key of the request slots shared by a ForEach
+// call, the calls nested in it and the API requests they make
+type requestLimiterKey struct{}
+
+// ForEach calls fn for each index in [0, n), running up to MaxParallelRequests
+// calls at a time. The API requests made by the calls, including those made
+// within nested ForEach calls, share one limit of MaxParallelRequests requests
+// in flight. Every call runs even if others fail; the errors are joined in
+// index order so the result does not depend on scheduling.
+func (c *CrucibleClient) ForEach(ctx context.Context, n int, fn func(ctx context.Context, i int) error) error {
+	if _, nested := ctx.Value(requestLimiterKey{}).(chan struct{}); !nested {
+		ctx = context.WithValue(ctx, requestLimiterKey{}, make(chan struct{}, c.MaxParallelRequests()))
+	}
+
+	errs := make([]error, n)
+	slots := make(chan struct{}, c.MaxParallelRequests())
+
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// acquireRequestSlot blocks until the ForEach call ctx belongs to has fewer
+// than MaxParallelRequests API requests in flight. Requests made outside ForEach
+// are not limited. The returned function releases the slot.
+func acquireRequestSlot(ctx context.Context) (func(), error) {
+	slots, ok := ctx.Value(requestLimiterKey{}).(chan struct{})
+	if !ok {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultRequestTimeout is used when no request timeout is configured and the
+// request context has no deadline of its own
+const DefaultRequestTimeout = 30 * time.Second
+
+// TransportConfig holds the TLS, proxy and timeout settings used for both API
+// calls and the OAuth2 token exchange
+type TransportConfig struct {
+	CACertFile         string
+	CACertPEM          string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+	HTTPProxy          string
+	RequestTimeout     time.Duration
+}
+
+// NewHTTPClient builds an HTTP client from the given transport settings.
+// ClientCert and ClientKey may each be either PEM-encoded content or a path to a PEM file.
+func NewHTTPClient(config TransportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	// Add custom CA certificates on top of the system pool
+	if config.CACertFile != "" || config.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if config.CACertFile != "" {
+			pemBytes, err := os.ReadFile(config.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pemBytes) {
+				return nil, fmt.Errorf("no valid certificates found in CA certificate file %s", config.CACertFile)
+			}
+		}
+
+		if config.CACertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(config.CACertPEM)) {
+				return nil, fmt.Errorf("no valid certificates found in CA certificate PEM")
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	// Load client certificate for mutual TLS
+	if config.ClientCert != "" || config.ClientKey != "" {
+		if config.ClientCert == "" || config.ClientKey == "" {
+			return nil, fmt.Errorf("client certificate and client key must be set together")
+		}
+
+		certPEM, err := readPEM(config.ClientCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client certificate: %w", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	// Use an explicit proxy if configured, otherwise honor the standard proxy environment variables
+	if config.HTTPProxy != "" {
+		proxyURL, err := url.Parse(config.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	// Without an explicit timeout, requests are bounded by their context: the
+	// resource operation deadline, or DefaultRequestTimeout if there is none
+	return &http.Client{
+		Transport: transport,
+		Timeout:   config.RequestTimeout,
+	}, nil
+}
+
+// readPEM returns value as PEM content if it contains a PEM block, otherwise
+// reads the file at that path
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return os.ReadFile(value)
+}
+
+// withDefaultTimeout applies DefaultRequestTimeout to requests that are bounded
+// neither by the HTTP client nor by a deadline on ctx, such as those made while
+// configuring the provider
+func (c *CrucibleClient) withDefaultTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || c.httpClient.Timeout > 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, DefaultRequestTimeout)
+}
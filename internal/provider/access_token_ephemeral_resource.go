@@ -0,0 +1,129 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &accessTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &accessTokenEphemeralResource{}
+)
+
+// NewAccessTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewAccessTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &accessTokenEphemeralResource{}
+}
+
+// accessTokenEphemeralResource opens a bearer token for the Crucible APIs
+// without writing it to state or plan.
+type accessTokenEphemeralResource struct {
+	client *client.CrucibleClient
+}
+
+// accessTokenEphemeralResourceModel describes the ephemeral resource data model.
+type accessTokenEphemeralResourceModel struct {
+	API         types.String `tfsdk:"api"`
+	AccessToken types.String `tfsdk:"access_token"`
+	ExpiresAt   types.String `tfsdk:"expires_at"`
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *accessTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_access_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *accessTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Provides a bearer token for the Crucible APIs, obtained with the provider's credentials, without storing it in state or plan. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"api": schema.StringAttribute{
+				Optional:    true,
+				Description: "The API the token is for (player, vm or caster), which selects that API's credentials if the provider configures them. Defaults to the provider-wide credentials.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(string(client.ServicePlayer), string(client.ServiceVM), string(client.ServiceCaster)),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token.",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "When the token expires, in RFC 3339 format. Null if the identity provider did not report an expiry.",
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *accessTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrucibleClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.CrucibleClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// Open obtains a token, reusing the provider's cached token while it is valid.
+func (r *accessTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_access_token.Open", &resp.Diagnostics)
+	defer endSpan()
+
+	var data accessTokenEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The token is not known until the provider configuration is
+	if !r.client.ConfigKnown() {
+		data.AccessToken = types.StringUnknown()
+		data.ExpiresAt = types.StringUnknown()
+		resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+		return
+	}
+
+	token, err := r.client.GetServiceOAuthToken(ctx, client.Service(data.API.ValueString()))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Obtaining Access Token",
+			fmt.Sprintf("Could not obtain a Crucible access token: %s", err.Error()),
+		)
+		return
+	}
+
+	data.AccessToken = types.StringValue(token.AccessToken)
+	data.ExpiresAt = types.StringNull()
+	if !token.Expiry.IsZero() {
+		data.ExpiresAt = types.StringValue(token.Expiry.UTC().Format(time.RFC3339))
+	}
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
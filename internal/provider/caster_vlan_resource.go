@@ -6,20 +6,28 @@ package provider
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -28,6 +36,7 @@ var (
 	_ resource.Resource                = &vlanResource{}
 	_ resource.ResourceWithConfigure   = &vlanResource{}
 	_ resource.ResourceWithImportState = &vlanResource{}
+	_ resource.ResourceWithIdentity    = &vlanResource{}
 )
 
 // NewVlanResource is a helper function to simplify the provider implementation.
@@ -42,11 +51,20 @@ type vlanResource struct {
 
 // vlanResourceModel describes the resource data model.
 type vlanResourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	PartitionID types.String   `tfsdk:"partition_id"`
+	PoolID      types.String   `tfsdk:"pool_id"`
+	ProjectID   types.String   `tfsdk:"project_id"`
+	Tag         types.String   `tfsdk:"tag"`
+	VlanID      types.Int64    `tfsdk:"vlan_id"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// vlanIdentityModel is the identity of a VLAN: its ID, and the partition and
+// VLAN number that also identify it.
+type vlanIdentityModel struct {
 	ID          types.String `tfsdk:"id"`
 	PartitionID types.String `tfsdk:"partition_id"`
-	PoolID      types.String `tfsdk:"pool_id"`
-	ProjectID   types.String `tfsdk:"project_id"`
-	Tag         types.String `tfsdk:"tag"`
 	VlanID      types.Int64  `tfsdk:"vlan_id"`
 }
 
@@ -56,9 +74,10 @@ func (r *vlanResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *vlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *vlanResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a VLAN allocation from the Caster API. VLANs can be allocated by partition or by project (mutually exclusive). Once created, VLAN resources are immutable and must be replaced if changes are needed.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -73,6 +92,7 @@ func (r *vlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "The partition ID to allocate a VLAN from. Conflicts with project_id. If neither is specified, a VLAN is allocated from the default partition.",
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.MatchRoot("project_id")),
+					validators.UUID(),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -87,6 +107,7 @@ func (r *vlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Description: "The project ID to allocate a VLAN for. Conflicts with partition_id.",
 				Validators: []validator.String{
 					stringvalidator.ConflictsWith(path.MatchRoot("partition_id")),
+					validators.UUID(),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -112,6 +133,34 @@ func (r *vlanResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a VLAN. It can be imported by id, or
+// by partition_id and vlan_id together.
+func (r *vlanResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the VLAN allocation.",
+			},
+			"partition_id": identityschema.StringAttribute{
+				OptionalForImport: true,
+				Description:       "The ID of the partition the VLAN belongs to. Requires vlan_id.",
+			},
+			"vlan_id": identityschema.Int64Attribute{
+				OptionalForImport: true,
+				Description:       "The VLAN number within the partition. Requires partition_id.",
+			},
+		},
 	}
 }
 
@@ -135,6 +184,9 @@ func (r *vlanResource) Configure(_ context.Context, req resource.ConfigureReques
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *vlanResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_vlan.Create", &resp.Diagnostics)
+	defer endSpan()
+
 	var data vlanResourceModel
 
 	// Read Terraform plan data into the model
@@ -143,6 +195,15 @@ func (r *vlanResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Build VLAN create command
 	cmd := &structs.VlanCreateCommand{
 		ProjectId:   data.ProjectID.ValueString(),
@@ -179,10 +240,19 @@ func (r *vlanResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setVlanIdentity(ctx, resp.Identity, &data, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *vlanResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_vlan.Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Keep the prior state until the provider configuration is known
+	if !r.client.ConfigKnown() {
+		return
+	}
+
 	var state vlanResourceModel
 
 	// Read current state
@@ -191,9 +261,24 @@ func (r *vlanResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Read VLAN from API
 	vlan, err := api.ReadVlan(ctx, r.client, state.ID.ValueString())
 	if err != nil {
+		// Remove from state if the VLAN was deleted outside of Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading VLAN",
 			fmt.Sprintf("Could not read VLAN %s: %s", state.ID.ValueString(), err.Error()),
@@ -215,10 +300,14 @@ func (r *vlanResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setVlanIdentity(ctx, resp.Identity, &state, &resp.Diagnostics)
 }
 
 // Update is not implemented - VLANs are immutable and require replacement.
 func (r *vlanResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_vlan.Update", &resp.Diagnostics)
+	defer endSpan()
+
 	resp.Diagnostics.AddError(
 		"Update Not Supported",
 		"VLAN resources are immutable. Any changes require resource replacement (ForceNew).",
@@ -227,6 +316,9 @@ func (r *vlanResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *vlanResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_vlan.Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	var state vlanResourceModel
 
 	// Read current state
@@ -235,8 +327,17 @@ func (r *vlanResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Delete (release) VLAN via API
-	if err := api.DeleteVlan(ctx, r.client, state.ID.ValueString()); err != nil {
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Delete (release) VLAN via API. A VLAN that no longer exists is already released.
+	if err := api.DeleteVlan(ctx, r.client, state.ID.ValueString()); err != nil && !errors.Is(err, client.ErrNotFound) {
 		resp.Diagnostics.AddError(
 			"Error Releasing VLAN",
 			fmt.Sprintf("Could not release VLAN %s: %s", state.ID.ValueString(), err.Error()),
@@ -245,8 +346,82 @@ func (r *vlanResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing VLAN by ID, or by its partition and VLAN
+// number with "<partition_id>/<vlan_id>" or the equivalent identity.
 func (r *vlanResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the VLAN ID as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if req.ID == "" && req.Identity != nil {
+		var identity vlanIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		switch {
+		case !identity.ID.IsNull():
+			resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		case !identity.PartitionID.IsNull() && !identity.VlanID.IsNull():
+			r.importFromPartition(ctx, identity.PartitionID.ValueString(), int(identity.VlanID.ValueInt64()), resp)
+		default:
+			resp.Diagnostics.AddError(
+				"Invalid Import Identity",
+				"Expected an identity with id, or with both partition_id and vlan_id.",
+			)
+		}
+		return
+	}
+
+	partitionID, vlanNumber, composite := strings.Cut(req.ID, "/")
+	if !composite {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	vlanID, err := strconv.Atoi(vlanNumber)
+	if partitionID == "" || err != nil || vlanID < 1 || vlanID > 4094 {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a VLAN ID or \"<partition_id>/<vlan_id>\" with a vlan_id between 1 and 4094, got: %q", req.ID),
+		)
+		return
+	}
+
+	r.importFromPartition(ctx, partitionID, vlanID, resp)
+}
+
+// importFromPartition imports the VLAN with the given number in a partition.
+func (r *vlanResource) importFromPartition(ctx context.Context, partitionID string, vlanID int, resp *resource.ImportStateResponse) {
+	if !requireKnownConfig(r.client, &resp.Diagnostics) {
+		return
+	}
+
+	id, err := api.FindVlanInPartition(ctx, r.client, partitionID, vlanID)
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Cannot Import Non-Existent Resource",
+				fmt.Sprintf("No VLAN %d was found in partition %s.", vlanID, partitionID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Importing Resource",
+			fmt.Sprintf("Could not look up VLAN %d in partition %s: %s", vlanID, partitionID, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// setVlanIdentity records the identity of a VLAN. identity is nil when
+// Terraform does not support resource identity.
+func setVlanIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, data *vlanResourceModel, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+	diags.Append(identity.Set(ctx, vlanIdentityModel{
+		ID:          data.ID,
+		PartitionID: data.PartitionID,
+		VlanID:      data.VlanID,
+	})...)
 }
@@ -0,0 +1,93 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &vlanListResource{}
+
+// NewVlanListResource is a helper function to simplify the provider implementation.
+func NewVlanListResource() list.ListResource {
+	return &vlanListResource{}
+}
+
+// vlanListResource lists the VLANs in a partition for terraform query.
+type vlanListResource struct {
+	listResourceClient
+}
+
+// vlanListConfigModel describes the filters of the VLAN list resource.
+type vlanListConfigModel struct {
+	PartitionID types.String `tfsdk:"partition_id"`
+	InUse       types.Bool   `tfsdk:"in_use"`
+}
+
+// Metadata returns the resource type name.
+func (r *vlanListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_vlan"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *vlanListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the VLANs in a Caster partition.",
+		Attributes: map[string]schema.Attribute{
+			"partition_id": schema.StringAttribute{
+				Required:    true,
+				Description: "The ID of the partition whose VLANs are listed.",
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+			"in_use": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list VLANs that are in use (true) or available (false).",
+			},
+		},
+	}
+}
+
+// List streams the VLANs that match the filters.
+func (r *vlanListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config vlanListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if !requireKnownConfig(r.client, &diags) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	partitionID := config.PartitionID.ValueString()
+	streamListResults(ctx, req, stream, "crucible_vlan.List", "VLAN", func(ctx context.Context, yield func(list.ListResult) error) error {
+		return api.ListVlansInPartition(ctx, r.client, partitionID, func(vlan structs.Vlan) error {
+			if !config.InUse.IsNull() && vlan.InUse != config.InUse.ValueBool() {
+				return nil
+			}
+
+			identity := vlanIdentityModel{
+				ID:          types.StringValue(vlan.Id),
+				PartitionID: types.StringValue(partitionID),
+				VlanID:      types.Int64Value(int64(vlan.VlanId)),
+			}
+			return yield(newListResult(ctx, req, identity, fmt.Sprintf("VLAN %d", vlan.VlanId), map[string]any{
+				"id":           vlan.Id,
+				"partition_id": partitionID,
+				"pool_id":      vlan.PoolId,
+				"tag":          vlan.Tag,
+				"vlan_id":      int64(vlan.VlanId),
+			}))
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 func TestAccVlanResource_WithPartition(t *testing.T) {
@@ -31,10 +32,28 @@ func TestAccVlanResource_WithPartition(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by partition and VLAN number testing
+			{
+				ResourceName:      "crucible_vlan.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccVlanImportID("crucible_vlan.test"),
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+// testAccVlanImportID returns the "<partition_id>/<vlan_id>" import ID of a VLAN in state
+func testAccVlanImportID(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return rs.Primary.Attributes["partition_id"] + "/" + rs.Primary.Attributes["vlan_id"], nil
+	}
+}
+
 func TestAccVlanResource_WithProject(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -99,3 +118,26 @@ resource "crucible_vlan" "test" {
 }
 `, poolID)
 }
+
+// TestUpgradeVLANStateV0 verifies that SDK and framework VLAN state upgrade without planning a replacement
+func TestUpgradeVLANStateV0(t *testing.T) {
+	var sdk vlanResourceModel
+	upgradeStateFixture(t, &vlanResource{}, 0, "vlan_v0_sdk.json", &sdk)
+
+	if !sdk.PartitionID.IsNull() {
+		t.Errorf("Expected partition_id \"\" to upgrade to null, got %s", sdk.PartitionID)
+	}
+	if sdk.ProjectID.ValueString() != "46420756-9421-41b7-99b4-1b6d2cba29b3" || sdk.Tag.ValueString() != "red" || sdk.VlanID.ValueInt64() != 10 {
+		t.Errorf("Unexpected upgraded VLAN: %+v", sdk)
+	}
+
+	var framework vlanResourceModel
+	upgradeStateFixture(t, &vlanResource{}, 0, "vlan_v0.json", &framework)
+
+	if framework.PartitionID.ValueString() != "c4d5e6f7-0a1b-4c2d-8e3f-4a5b6c7d8e9f" || !framework.ProjectID.IsNull() || !framework.Tag.IsNull() {
+		t.Errorf("Unexpected upgraded VLAN: %+v", framework)
+	}
+	if framework.Timeouts.IsNull() {
+		t.Errorf("Expected timeouts to be kept")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var (
+	_ resource.ResourceWithUpgradeState = &vlanResource{}
+	_ resource.ResourceWithMoveState    = &vlanResource{}
+)
+
+// UpgradeState upgrades state written by earlier versions of the VLAN schema.
+func (r *vlanResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeVLANStateV0},
+	}
+}
+
+// MoveState accepts VLAN state written under another
+// Crucible provider address or a former type name.
+func (r *vlanResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromCrucible("crucible_vlan", formerTypeNames["crucible_vlan"], r.UpgradeState(ctx)),
+	}
+}
+
+// upgradeVLANStateV0 converts version 0 state to the current schema. The SDK
+// releases stored an unset project_id, partition_id or tag as "". Changing them
+// requires replacement, so keeping "" would release the VLAN and acquire
+// another on the next apply.
+func upgradeVLANStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	prior := newPriorState(req.RawState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := vlanResourceModel{
+		ID:          prior.String("id"),
+		PartitionID: prior.String("partition_id"),
+		PoolID:      prior.String("pool_id"),
+		ProjectID:   prior.String("project_id"),
+		Tag:         prior.String("tag"),
+		VlanID:      prior.Int64("vlan_id"),
+		Timeouts:    prior.Timeouts("create", "read", "delete"),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Values of on_conflict
+const (
+	onConflictError = "error"
+	onConflictAdopt = "adopt"
+)
+
+// onConflictAttribute returns the schema for the on_conflict attribute of a
+// resource whose creation can conflict with an existing object.
+func onConflictAttribute(objectName, matchedBy string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+		Default:  stringdefault.StaticString(onConflictError),
+		Description: fmt.Sprintf("What to do if an existing %s has the same %s when creating this resource. "+
+			"\"error\" fails the apply; \"adopt\" takes ownership of the existing %s and updates it to match the configuration. Defaults to \"error\".",
+			objectName, matchedBy, objectName),
+		Validators: []validator.String{
+			stringvalidator.OneOf(onConflictError, onConflictAdopt),
+		},
+	}
+}
+
+// onConflictOrDefault returns value, or the default for imported resources
+// where on_conflict has never been set.
+func onConflictOrDefault(value types.String) types.String {
+	if value.IsNull() {
+		return types.StringValue(onConflictError)
+	}
+	return value
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema for the deletion_protection
+// attribute of a resource that is expensive to lose by mistake.
+func deletionProtectionAttribute(objectName string) schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+		Description: fmt.Sprintf("Whether Terraform is prevented from deleting this %s, including when it must be replaced. Set to false and apply before destroying it. Defaults to false.", objectName),
+	}
+}
+
+// boolOrDefault returns value, or defaultValue for imported resources where
+// the attribute has never been set.
+func boolOrDefault(value types.Bool, defaultValue bool) types.Bool {
+	if value.IsNull() {
+		return types.BoolValue(defaultValue)
+	}
+	return value
+}
+
+// addDeletionProtectedError reports that Delete was refused by deletion_protection.
+func addDeletionProtectedError(diags *diag.Diagnostics, objectName, id string) {
+	diags.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("Cannot delete %s %s because deletion_protection is true. Set deletion_protection = false and apply before destroying or replacing it.", objectName, id),
+	)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction runs f with the given string arguments and returns its result.
+func runFunction(f function.Function, result attr.Value, args ...string) (attr.Value, *function.FuncError) {
+	ctx := context.Background()
+
+	values := make([]attr.Value, len(args))
+	for i, arg := range args {
+		values[i] = types.StringValue(arg)
+	}
+
+	resp := &function.RunResponse{Result: function.NewResultData(result)}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(values)}, resp)
+	return resp.Result.Value(), resp.Error
+}
+
+// TestParseProxmoxIDFunction verifies both forms of Proxmox ID and the errors for malformed ones
+func TestParseProxmoxIDFunction(t *testing.T) {
+	attrTypes := map[string]attr.Type{"node": types.StringType, "type": types.StringType, "vm_id": types.Int64Type}
+
+	tests := []struct {
+		id       string
+		expected map[string]attr.Value
+	}{
+		{"pve/qemu/100", map[string]attr.Value{"node": types.StringValue("pve"), "type": types.StringValue("QEMU"), "vm_id": types.Int64Value(100)}},
+		{"2001", map[string]attr.Value{"node": types.StringNull(), "type": types.StringNull(), "vm_id": types.Int64Value(2001)}},
+	}
+
+	for _, test := range tests {
+		got, err := runFunction(&parseProxmoxIDFunction{}, types.ObjectUnknown(attrTypes), test.id)
+		if err != nil {
+			t.Fatalf("parse_proxmox_id(%q) returned error: %s", test.id, err)
+		}
+		if expected := types.ObjectValueMust(attrTypes, test.expected); !got.Equal(expected) {
+			t.Errorf("parse_proxmox_id(%q) = %s, expected %s", test.id, got, expected)
+		}
+	}
+
+	if _, err := runFunction(&parseProxmoxIDFunction{}, types.ObjectUnknown(attrTypes), "pve/vm/100"); err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+		t.Errorf("Expected an argument error for an invalid type, got: %v", err)
+	}
+}
+
+// TestURLFunctions verifies the URLs built by the URL functions
+func TestURLFunctions(t *testing.T) {
+	const id = "46420756-9421-41b7-99b4-1b6d2cba29b3"
+
+	tests := []struct {
+		name     string
+		function function.Function
+		args     []string
+		expected string
+	}{
+		{"player_view_url", NewPlayerViewURLFunction(), []string{"https://player.example.com/", id}, "https://player.example.com/view/" + id},
+		{"vm_console_url", NewVMConsoleURLFunction(), []string{"https://console.example.com", id}, "https://console.example.com/vm/" + id + "/console"},
+		{"normalize_api_url", &normalizeAPIURLFunction{}, []string{"https://player.example.com/api"}, "https://player.example.com/api/"},
+		{"normalize_api_url bare", &normalizeAPIURLFunction{}, []string{"https://vm.example.com/"}, "https://vm.example.com/api/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := runFunction(test.function, types.StringUnknown(), test.args...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if !got.Equal(types.StringValue(test.expected)) {
+				t.Errorf("Expected %q, got %s", test.expected, got)
+			}
+		})
+	}
+}
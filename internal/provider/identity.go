@@ -0,0 +1,41 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// idIdentityModel is the identity of a resource identified by its ID alone.
+type idIdentityModel struct {
+	ID types.String `tfsdk:"id"`
+}
+
+// idIdentitySchema returns the identity schema of a resource identified by
+// the ID of objectName, which never changes once the object is created.
+func idIdentitySchema(objectName string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       fmt.Sprintf("The ID of the %s.", objectName),
+			},
+		},
+	}
+}
+
+// setIDIdentity records id as the identity of a resource. identity is nil
+// when Terraform does not support resource identity.
+func setIDIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, id types.String, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+	diags.Append(identity.Set(ctx, idIdentityModel{ID: id})...)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// importNamePrefix marks an import ID that is a name rather than an ID, as in
+// terraform import crucible_player_view.x "name:My Exercise".
+const importNamePrefix = "name:"
+
+// importByIDOrName imports the object with the ID given on import or in its
+// identity, or looks up the ID of a "name:<name>" import ID with find. Only the
+// lookup needs c, the client, to have been built from known configuration.
+func importByIDOrName(ctx context.Context, c *client.CrucibleClient, req resource.ImportStateRequest, resp *resource.ImportStateResponse, objectName string, find func(ctx context.Context, name string) (string, error)) {
+	name, byName := strings.CutPrefix(req.ID, importNamePrefix)
+	if !byName {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+
+	if name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected a %s ID or %q followed by its name, got: %q", objectName, importNamePrefix, req.ID),
+		)
+		return
+	}
+
+	if !requireKnownConfig(c, &resp.Diagnostics) {
+		return
+	}
+
+	id, err := find(ctx, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, client.ErrNotFound):
+			resp.Diagnostics.AddError(
+				"Cannot Import Non-Existent Resource",
+				fmt.Sprintf("No %s named %q was found.", objectName, name),
+			)
+		case errors.Is(err, api.ErrAmbiguous):
+			resp.Diagnostics.AddError(
+				"Ambiguous Import Name",
+				fmt.Sprintf("Could not import %s by name: %s. Import it by ID instead.", objectName, err.Error()),
+			)
+		default:
+			resp.Diagnostics.AddError(
+				"Error Importing Resource",
+				fmt.Sprintf("Could not look up %s %q: %s", objectName, name, err.Error()),
+			)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
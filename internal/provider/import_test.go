@@ -0,0 +1,121 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestImportByIDOrName verifies import IDs given as IDs and as names
+func TestImportByIDOrName(t *testing.T) {
+	find := func(_ context.Context, name string) (string, error) {
+		switch name {
+		case "My Exercise":
+			return "view-1", nil
+		case "Duplicate":
+			return "", fmt.Errorf("2 views are named 'Duplicate' (view-2, view-3): %w", api.ErrAmbiguous)
+		default:
+			return "", fmt.Errorf("view '%s': %w", name, client.ErrNotFound)
+		}
+	}
+
+	tests := []struct {
+		importID  string
+		expected  string
+		wantError string
+	}{
+		{"7c8d9e0f-1a2b-4c3d-9e4f-5a6b7c8d9e0f", "7c8d9e0f-1a2b-4c3d-9e4f-5a6b7c8d9e0f", ""},
+		{"name:My Exercise", "view-1", ""},
+		{"name:Duplicate", "", "Ambiguous Import Name"},
+		{"name:Missing", "", "Cannot Import Non-Existent Resource"},
+		{"name:", "", "Invalid Import ID"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.importID, func(t *testing.T) {
+			ctx := context.Background()
+			resp := &resource.ImportStateResponse{State: emptyState(ctx, &viewResource{})}
+
+			importByIDOrName(ctx, client.NewClient(&client.ProviderConfig{}), resource.ImportStateRequest{ID: test.importID}, resp, "view", find)
+
+			if test.wantError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != test.wantError {
+					t.Fatalf("Expected error %q, got: %v", test.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if id.ValueString() != test.expected {
+				t.Errorf("Expected id %q, got %s", test.expected, id)
+			}
+		})
+	}
+}
+
+// TestVlanImportState_InvalidComposite verifies that malformed partition/vlan_id import IDs are rejected before any lookup
+func TestVlanImportState_InvalidComposite(t *testing.T) {
+	for _, importID := range []string{"/10", "partition/0", "partition/4095", "partition/ten", "partition/10/extra"} {
+		ctx := context.Background()
+		resp := &resource.ImportStateResponse{State: emptyState(ctx, &vlanResource{})}
+
+		(&vlanResource{}).ImportState(ctx, resource.ImportStateRequest{ID: importID}, resp)
+
+		if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import ID" {
+			t.Errorf("Expected import ID %q to be rejected, got: %v", importID, resp.Diagnostics)
+		}
+	}
+}
+
+// TestVlanImportState_Identity verifies VLAN imports from an identity instead of an import ID
+func TestVlanImportState_Identity(t *testing.T) {
+	ctx := context.Background()
+	r := &vlanResource{}
+
+	var schemaResp resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+	identity := func(id, partitionID *string) *tfsdk.ResourceIdentity {
+		return &tfsdk.ResourceIdentity{
+			Schema: schemaResp.IdentitySchema,
+			Raw: tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+				"id":           tftypes.NewValue(tftypes.String, id),
+				"partition_id": tftypes.NewValue(tftypes.String, partitionID),
+				"vlan_id":      tftypes.NewValue(tftypes.Number, nil),
+			}),
+		}
+	}
+
+	id, partitionID := "vlan-1", "partition-1"
+
+	resp := &resource.ImportStateResponse{State: emptyState(ctx, r)}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity(&id, nil)}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+	}
+	var got types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &got)
+	if got.ValueString() != id {
+		t.Errorf("Expected id %q, got %s", id, got)
+	}
+
+	resp = &resource.ImportStateResponse{State: emptyState(ctx, r)}
+	r.ImportState(ctx, resource.ImportStateRequest{Identity: identity(nil, &partitionID)}, resp)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Invalid Import Identity" {
+		t.Errorf("Expected a partition_id without vlan_id to be rejected, got: %v", resp.Diagnostics)
+	}
+}
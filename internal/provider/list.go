@@ -0,0 +1,109 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// listResourceClient holds the client of a list resource. List resources
+// embed it for their Configure method.
+type listResourceClient struct {
+	client *client.CrucibleClient
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *listResourceClient) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.CrucibleClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.CrucibleClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+// namePrefixAttribute returns the schema for the name_prefix filter of a list
+// resource.
+func namePrefixAttribute(objectName string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Optional:    true,
+		Description: fmt.Sprintf("Only list %ss whose name starts with this prefix. The match is case-sensitive.", objectName),
+	}
+}
+
+// hasNamePrefix reports whether name starts with prefix, or prefix is unset.
+func hasNamePrefix(name string, prefix types.String) bool {
+	return prefix.IsNull() || strings.HasPrefix(name, prefix.ValueString())
+}
+
+// streamListResults sets the results of a list request to those that
+// listObjects yields, in a span named spanName. Listing stops once req.Limit
+// results have been returned or Terraform stops reading them, and an error
+// from listObjects is returned as a final result.
+func streamListResults(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream, spanName, objectName string, listObjects func(ctx context.Context, yield func(list.ListResult) error) error) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		var diags diag.Diagnostics
+		ctx, endSpan := startSpan(ctx, spanName, &diags)
+		defer endSpan()
+
+		var count int64
+		err := listObjects(ctx, func(result list.ListResult) error {
+			if !push(result) {
+				return client.ErrStopPaging
+			}
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return client.ErrStopPaging
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, client.ErrStopPaging) {
+			diags.AddError(
+				"Error Listing Resources",
+				fmt.Sprintf("Could not list %ss: %s", objectName, err.Error()),
+			)
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
+
+// newListResult returns the list result for an object with the given identity
+// and display name. When the request includes resources, attributes are set
+// on the result's resource. Attributes the list does not return, and empty
+// strings, are left null.
+func newListResult(ctx context.Context, req list.ListRequest, identity any, displayName string, attributes map[string]any) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = displayName
+	result.Diagnostics.Append(result.Identity.Set(ctx, identity)...)
+
+	if req.IncludeResource {
+		for name, value := range attributes {
+			if value == "" {
+				continue
+			}
+			result.Diagnostics.Append(result.Resource.SetAttribute(ctx, path.Root(name), value)...)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestStreamListResults verifies that listing stops at the limit and reports errors as a final result
+func TestStreamListResults(t *testing.T) {
+	ctx := context.Background()
+
+	// listNames yields a result for each name, then fails with err if it is set
+	listNames := func(err error, names ...string) func(context.Context, func(list.ListResult) error) error {
+		return func(_ context.Context, yield func(list.ListResult) error) error {
+			for _, name := range names {
+				if err := yield(list.ListResult{DisplayName: name}); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+
+	collect := func(limit int64, listObjects func(context.Context, func(list.ListResult) error) error) []list.ListResult {
+		var stream list.ListResultsStream
+		streamListResults(ctx, list.ListRequest{Limit: limit}, &stream, "test.List", "widget", listObjects)
+
+		var results []list.ListResult
+		for result := range stream.Results {
+			results = append(results, result)
+		}
+		return results
+	}
+
+	if results := collect(0, listNames(nil, "a", "b", "c")); len(results) != 3 {
+		t.Errorf("Expected 3 results without a limit, got %d", len(results))
+	}
+
+	results := collect(2, listNames(errors.New("not reached"), "a", "b", "c"))
+	if len(results) != 2 || results[1].DisplayName != "b" {
+		t.Errorf("Expected listing to stop after 2 results, got %+v", results)
+	}
+
+	results = collect(0, listNames(fmt.Errorf("API returned status 500"), "a"))
+	if len(results) != 2 || !results[1].Diagnostics.HasError() || results[1].Diagnostics.Errors()[0].Summary() != "Error Listing Resources" {
+		t.Errorf("Expected the error as the last result, got %+v", results)
+	}
+
+	// Terraform stops reading after the first result
+	var stream list.ListResultsStream
+	streamListResults(ctx, list.ListRequest{}, &stream, "test.List", "widget", listNames(nil, "a", "b"))
+	for range stream.Results {
+		break
+	}
+}
+
+// TestHasNamePrefix verifies name_prefix filtering
+func TestHasNamePrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   types.String
+		expected bool
+	}{
+		{"Exercise 1", types.StringNull(), true},
+		{"Exercise 1", types.StringValue("Exercise"), true},
+		{"Exercise 1", types.StringValue("exercise"), false},
+		{"Training", types.StringValue("Exercise"), false},
+	}
+
+	for _, test := range tests {
+		if got := hasNamePrefix(test.name, test.prefix); got != test.expected {
+			t.Errorf("hasNamePrefix(%q, %s) = %v, expected %v", test.name, test.prefix, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &normalizeAPIURLFunction{}
+
+// NewNormalizeAPIURLFunction is a helper function to simplify the provider implementation.
+func NewNormalizeAPIURLFunction() function.Function {
+	return &normalizeAPIURLFunction{}
+}
+
+// normalizeAPIURLFunction normalizes an API URL as the provider does.
+type normalizeAPIURLFunction struct{}
+
+// Metadata returns the function name.
+func (f *normalizeAPIURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_api_url"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *normalizeAPIURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalize a Crucible API URL",
+		Description: "Normalizes a Crucible API URL the way the provider does for player_api_url, vm_api_url and caster_api_url: " +
+			"a trailing \"/\" and \"/api\" are removed and \"/api/\" is appended, so \"https://player.example.com\" and " +
+			"\"https://player.example.com/api\" both become \"https://player.example.com/api/\".",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "url",
+				Description: "The API URL to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run normalizes the URL with the client's rules.
+func (f *normalizeAPIURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var url string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &url))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, client.NormalizeAPIURL(url)))
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &parseProxmoxIDFunction{}
+
+// NewParseProxmoxIDFunction is a helper function to simplify the provider implementation.
+func NewParseProxmoxIDFunction() function.Function {
+	return &parseProxmoxIDFunction{}
+}
+
+// parseProxmoxIDFunction parses a Proxmox VM ID as proxmox_vm_info.id does.
+type parseProxmoxIDFunction struct{}
+
+// parsedProxmoxIDModel describes the object returned by parse_proxmox_id.
+type parsedProxmoxIDModel struct {
+	Node types.String `tfsdk:"node"`
+	Type types.String `tfsdk:"type"`
+	VMID types.Int64  `tfsdk:"vm_id"`
+}
+
+// Metadata returns the function name.
+func (f *parseProxmoxIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_proxmox_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseProxmoxIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a Proxmox VM ID",
+		Description: "Parses a Proxmox VM ID, given either as a number such as \"100\" or in the Proxmox provider's \"node/qemu/100\" form, " +
+			"into an object with node, type (QEMU or LXC) and vm_id. node and type are null for a plain number.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The Proxmox VM ID to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"node":  types.StringType,
+				"type":  types.StringType,
+				"vm_id": types.Int64Type,
+			},
+		},
+	}
+}
+
+// Run parses the ID with the same rules as the VM resource.
+func (f *parseProxmoxIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseProxmoxID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	result := parsedProxmoxIDModel{
+		Node: types.StringNull(),
+		Type: types.StringNull(),
+		VMID: types.Int64Value(int64(parsed.VMID)),
+	}
+	if parsed.Node != "" {
+		result.Node = types.StringValue(parsed.Node)
+		result.Type = types.StringValue(parsed.Type)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -5,12 +5,14 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -19,6 +21,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,6 +29,7 @@ var (
 	_ resource.Resource                = &appTemplateResource{}
 	_ resource.ResourceWithConfigure   = &appTemplateResource{}
 	_ resource.ResourceWithImportState = &appTemplateResource{}
+	_ resource.ResourceWithIdentity    = &appTemplateResource{}
 )
 
 // NewAppTemplateResource is a helper function to simplify the provider implementation.
@@ -40,12 +44,14 @@ type appTemplateResource struct {
 
 // appTemplateResourceModel describes the resource data model.
 type appTemplateResourceModel struct {
-	ID               types.String `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	URL              types.String `tfsdk:"url"`
-	Icon             types.String `tfsdk:"icon"`
-	Embeddable       types.Bool   `tfsdk:"embeddable"`
-	LoadInBackground types.Bool   `tfsdk:"load_in_background"`
+	ID               types.String   `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	URL              types.String   `tfsdk:"url"`
+	Icon             types.String   `tfsdk:"icon"`
+	Embeddable       types.Bool     `tfsdk:"embeddable"`
+	LoadInBackground types.Bool     `tfsdk:"load_in_background"`
+	OnConflict       types.String   `tfsdk:"on_conflict"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -54,9 +60,10 @@ func (r *appTemplateResource) Metadata(_ context.Context, req resource.MetadataR
 }
 
 // Schema defines the schema for the resource.
-func (r *appTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *appTemplateResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Player application template in Crucible. Application templates define reusable application configurations that can be instantiated within views.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -73,7 +80,7 @@ func (r *appTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Optional:    true,
 				Description: "The URL of the application.",
 				Validators: []validator.String{
-					stringvalidator.LengthAtLeast(1),
+					validators.AbsoluteURL(),
 				},
 			},
 			"icon": schema.StringAttribute{
@@ -92,10 +99,24 @@ func (r *appTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Default:     booldefault.StaticBool(false),
 				Description: "Whether this application should be loaded in the background when the view is opened.",
 			},
+			"on_conflict": onConflictAttribute("application template", "name"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// IdentitySchema defines the identity of a application template, its ID.
+func (r *appTemplateResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("application template")
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *appTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -116,6 +137,9 @@ func (r *appTemplateResource) Configure(_ context.Context, req resource.Configur
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *appTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_application_template.Create", &resp.Diagnostics)
+	defer endSpan()
+
 	var data appTemplateResourceModel
 
 	// Read Terraform plan data into the model
@@ -124,6 +148,15 @@ func (r *appTemplateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Build template struct
 	template := &structs.AppTemplate{
 		Name:             data.Name.ValueString(),
@@ -135,6 +168,12 @@ func (r *appTemplateResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Create template via API
 	id, err := api.CreateAppTemplate(ctx, r.client, template)
+
+	// Adopt an existing template with the same name by updating it to match the plan
+	if errors.Is(err, client.ErrConflict) && data.OnConflict.ValueString() == onConflictAdopt {
+		id, err = r.adopt(ctx, template)
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Application Template",
@@ -148,10 +187,35 @@ func (r *appTemplateResource) Create(ctx context.Context, req resource.CreateReq
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
+}
+
+// adopt finds the existing application template with the same name and updates it to match template.
+func (r *appTemplateResource) adopt(ctx context.Context, template *structs.AppTemplate) (string, error) {
+	id, err := api.FindAppTemplateByName(ctx, r.client, template.Name)
+	if err != nil {
+		return "", err
+	}
+
+	tflog.Info(ctx, "Adopting existing application template", map[string]interface{}{"id": id})
+
+	if err := api.AppTemplateUpdate(ctx, r.client, id, template); err != nil {
+		return "", err
+	}
+
+	return id, nil
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *appTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_application_template.Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Keep the prior state until the provider configuration is known
+	if !r.client.ConfigKnown() {
+		return
+	}
+
 	var state appTemplateResourceModel
 
 	// Read current state
@@ -160,25 +224,24 @@ func (r *appTemplateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
-	// Check if template exists
-	exists, err := api.AppTemplateExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking Application Template Existence",
-			fmt.Sprintf("Could not verify if application template %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If template doesn't exist, remove from state
-	if !exists {
-		resp.State.RemoveResource(ctx)
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Read template from API
 	template, err := api.AppTemplateRead(ctx, r.client, state.ID.ValueString())
 	if err != nil {
+		// Remove from state if the application template was deleted outside of Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Application Template",
 			fmt.Sprintf("Could not read application template %s: %s", state.ID.ValueString(), err.Error()),
@@ -193,12 +256,19 @@ func (r *appTemplateResource) Read(ctx context.Context, req resource.ReadRequest
 	state.Embeddable = types.BoolValue(template.Embeddable)
 	state.LoadInBackground = types.BoolValue(template.LoadInBackground)
 
+	// on_conflict is not stored in Player, so default it for imported templates
+	state.OnConflict = onConflictOrDefault(state.OnConflict)
+
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setIDIdentity(ctx, resp.Identity, state.ID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *appTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_application_template.Update", &resp.Diagnostics)
+	defer endSpan()
+
 	var data appTemplateResourceModel
 
 	// Read Terraform plan data into the model
@@ -207,6 +277,15 @@ func (r *appTemplateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Build template struct
 	template := &structs.AppTemplate{
 		Name:             data.Name.ValueString(),
@@ -227,10 +306,14 @@ func (r *appTemplateResource) Update(ctx context.Context, req resource.UpdateReq
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *appTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_application_template.Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	var state appTemplateResourceModel
 
 	// Read current state
@@ -239,20 +322,14 @@ func (r *appTemplateResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
-	// Check if template exists
-	exists, err := api.AppTemplateExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking Application Template Existence",
-			fmt.Sprintf("Could not verify if application template %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If template doesn't exist, nothing to delete
-	if !exists {
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	// Delete template via API
 	if err := api.DeleteAppTemplate(ctx, r.client, state.ID.ValueString()); err != nil {
@@ -264,8 +341,8 @@ func (r *appTemplateResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing application template by ID, given on
+// import or in its identity.
 func (r *appTemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the template ID as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -0,0 +1,74 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &appTemplateListResource{}
+
+// NewAppTemplateListResource is a helper function to simplify the provider implementation.
+func NewAppTemplateListResource() list.ListResource {
+	return &appTemplateListResource{}
+}
+
+// appTemplateListResource lists existing application templates for terraform query.
+type appTemplateListResource struct {
+	listResourceClient
+}
+
+// appTemplateListConfigModel describes the filters of the application template list resource.
+type appTemplateListConfigModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// Metadata returns the resource type name.
+func (r *appTemplateListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_player_application_template"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *appTemplateListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing Player application templates.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": namePrefixAttribute("application template"),
+		},
+	}
+}
+
+// List streams the application templates that match the filters.
+func (r *appTemplateListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config appTemplateListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if !requireKnownConfig(r.client, &diags) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, "crucible_player_application_template.List", "application template", func(ctx context.Context, yield func(list.ListResult) error) error {
+		return api.ListAppTemplates(ctx, r.client, func(template structs.AppTemplate) error {
+			if !hasNamePrefix(template.Name, config.NamePrefix) {
+				return nil
+			}
+
+			return yield(newListResult(ctx, req, idIdentityModel{ID: types.StringValue(template.ID)}, template.Name, map[string]any{
+				"id":                 template.ID,
+				"name":               template.Name,
+				"url":                template.URL,
+				"icon":               template.Icon,
+				"embeddable":         template.Embeddable,
+				"load_in_background": template.LoadInBackground,
+			}))
+		})
+	})
+}
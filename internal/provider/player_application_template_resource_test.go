@@ -4,9 +4,15 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -86,3 +92,59 @@ resource "crucible_player_application_template" "test" {
 }
 `, name)
 }
+
+// TestUpgradeAppTemplateStateV0 verifies that SDK application template state upgrades unchanged
+func TestUpgradeAppTemplateStateV0(t *testing.T) {
+	var state appTemplateResourceModel
+	upgradeStateFixture(t, &appTemplateResource{}, 0, "player_application_template_v0_sdk.json", &state)
+
+	if state.ID.ValueString() != "9f2c4e1a-8b3d-4c5e-a6f7-0b1c2d3e4f50" || state.Name.ValueString() != "Example" || state.URL.ValueString() != "http://example.com" {
+		t.Errorf("Unexpected upgraded application template: %+v", state)
+	}
+	if state.Embeddable.ValueBool() || state.LoadInBackground.IsNull() || state.LoadInBackground.ValueBool() {
+		t.Errorf("Unexpected upgraded booleans: embeddable %s, load_in_background %s", state.Embeddable, state.LoadInBackground)
+	}
+	if state.OnConflict.ValueString() != onConflictError {
+		t.Errorf("Expected on_conflict to default to %q, got %s", onConflictError, state.OnConflict)
+	}
+}
+
+// TestAppTemplateCreate_AdoptAmbiguousName verifies that on_conflict = "adopt" refuses to pick between templates that share a name
+func TestAppTemplateCreate_AdoptAmbiguousName(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []string
+	r := &appTemplateResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+
+		switch req.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusConflict)
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `[{"id": "template-1", "name": "Map"}, {"id": "template-2", "name": "Map"}, {"id": "template-3", "name": "Chat"}]`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})}
+
+	state := emptyState(ctx, r)
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+	plan.SetAttribute(ctx, path.Root("name"), "Map")
+	plan.SetAttribute(ctx, path.Root("on_conflict"), onConflictAdopt)
+
+	resp := &fwresource.CreateResponse{State: state}
+	r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "2 application templates are named 'Map' (template-1, template-2)") {
+		t.Fatalf("Expected an ambiguous name error, got: %v", resp.Diagnostics)
+	}
+	for _, request := range requests {
+		if strings.HasPrefix(request, http.MethodPut) {
+			t.Errorf("Expected no template to be updated, got requests %v", requests)
+		}
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Errorf("Expected no state, got %s", resp.State.Raw)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var (
+	_ resource.ResourceWithUpgradeState = &appTemplateResource{}
+	_ resource.ResourceWithMoveState    = &appTemplateResource{}
+)
+
+// UpgradeState upgrades state written by earlier versions of the application
+// template schema.
+func (r *appTemplateResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeAppTemplateStateV0},
+	}
+}
+
+// MoveState accepts application template state written under another
+// Crucible provider address or a former type name.
+func (r *appTemplateResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromCrucible("crucible_player_application_template", formerTypeNames["crucible_player_application_template"], r.UpgradeState(ctx)),
+	}
+}
+
+// upgradeAppTemplateStateV0 converts version 0 state to the current schema.
+// The SDK releases stored an unset url or icon as "".
+func upgradeAppTemplateStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	prior := newPriorState(req.RawState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := appTemplateResourceModel{
+		ID:               prior.String("id"),
+		Name:             prior.String("name"),
+		URL:              prior.String("url"),
+		Icon:             prior.String("icon"),
+		Embeddable:       boolOrDefault(prior.Bool("embeddable"), false),
+		LoadInBackground: boolOrDefault(prior.Bool("load_in_background"), false),
+		OnConflict:       onConflictOrDefault(prior.String("on_conflict")),
+		Timeouts:         prior.Timeouts("create", "read", "update", "delete"),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
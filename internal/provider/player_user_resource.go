@@ -5,20 +5,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
-	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,6 +27,7 @@ var (
 	_ resource.Resource                = &playerUserResource{}
 	_ resource.ResourceWithConfigure   = &playerUserResource{}
 	_ resource.ResourceWithImportState = &playerUserResource{}
+	_ resource.ResourceWithIdentity    = &playerUserResource{}
 )
 
 // NewPlayerUserResource is a helper function to simplify the provider implementation.
@@ -40,10 +42,12 @@ type playerUserResource struct {
 
 // playerUserResourceModel describes the resource data model.
 type playerUserResourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	UserID types.String `tfsdk:"user_id"`
-	Name   types.String `tfsdk:"name"`
-	Role   types.String `tfsdk:"role"`
+	ID         types.String   `tfsdk:"id"`
+	UserID     types.String   `tfsdk:"user_id"`
+	Name       types.String   `tfsdk:"name"`
+	Role       types.String   `tfsdk:"role"`
+	OnConflict types.String   `tfsdk:"on_conflict"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -52,9 +56,10 @@ func (r *playerUserResource) Metadata(_ context.Context, req resource.MetadataRe
 }
 
 // Schema defines the schema for the resource.
-func (r *playerUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *playerUserResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Player user resource in Crucible. Users are identity accounts that can be assigned to teams within views.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -67,10 +72,7 @@ func (r *playerUserResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Required:    true,
 				Description: "UUID of the user in the identity provider. Must be a valid UUID.",
 				Validators: []validator.String{
-					stringvalidator.RegexMatches(
-						regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
-						"must be a valid UUID (lowercase with hyphens)",
-					),
+					validators.UUID(),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -83,11 +85,28 @@ func (r *playerUserResource) Schema(_ context.Context, _ resource.SchemaRequest,
 			"role": schema.StringAttribute{
 				Optional:    true,
 				Description: "Role name for this user (e.g., 'Member', 'Admin'). Leave unset if no default role is needed.",
+				Validators: []validator.String{
+					validators.RoleName(),
+				},
 			},
+			"on_conflict": onConflictAttribute("user", "user_id"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// IdentitySchema defines the identity of a user, its ID.
+func (r *playerUserResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("user")
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *playerUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -108,6 +127,9 @@ func (r *playerUserResource) Configure(_ context.Context, req resource.Configure
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *playerUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_user.Create", &resp.Diagnostics)
+	defer endSpan()
+
 	var data playerUserResourceModel
 
 	// Read Terraform plan data into the model
@@ -116,6 +138,15 @@ func (r *playerUserResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Build user struct
 	user := &structs.PlayerUser{
 		ID:   data.UserID.ValueString(),
@@ -130,7 +161,16 @@ func (r *playerUserResource) Create(ctx context.Context, req resource.CreateRequ
 	}
 
 	// Create user via API
-	if err := api.CreateUser(ctx, r.client, user); err != nil {
+	role := user.Role
+	err := api.CreateUser(ctx, r.client, user)
+
+	// Adopt a user left behind by a previous run by updating it to match the plan
+	if errors.Is(err, client.ErrConflict) && data.OnConflict.ValueString() == onConflictAdopt {
+		user.Role = role
+		err = r.adopt(ctx, user)
+	}
+
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Player User",
 			fmt.Sprintf("Could not create user %s: %s", data.UserID.ValueString(), err.Error()),
@@ -143,10 +183,42 @@ func (r *playerUserResource) Create(ctx context.Context, req resource.CreateRequ
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
+}
+
+// adopt updates the existing user with user.ID to match user. The existing user
+// is read first so that a role the configuration leaves unset is kept.
+func (r *playerUserResource) adopt(ctx context.Context, user *structs.PlayerUser) error {
+	existing, err := api.ReadUser(ctx, r.client, user.ID)
+	if err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, "Adopting existing player user", map[string]interface{}{"id": user.ID})
+
+	if role, _ := user.Role.(string); role == "" {
+		if roleID, ok := existing.Role.(string); ok && roleID != "" {
+			roleName, err := api.GetRoleByID(ctx, r.client, roleID)
+			if err != nil {
+				return err
+			}
+			user.Role = roleName
+		}
+	}
+
+	return api.UpdateUser(ctx, r.client, user)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *playerUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_user.Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Keep the prior state until the provider configuration is known
+	if !r.client.ConfigKnown() {
+		return
+	}
+
 	var state playerUserResourceModel
 
 	// Read current state
@@ -155,9 +227,24 @@ func (r *playerUserResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Read user from API
 	user, err := api.ReadUser(ctx, r.client, state.ID.ValueString())
 	if err != nil {
+		// Remove from state if the user was deleted outside of Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Player User",
 			fmt.Sprintf("Could not read user %s: %s", state.ID.ValueString(), err.Error()),
@@ -189,12 +276,19 @@ func (r *playerUserResource) Read(ctx context.Context, req resource.ReadRequest,
 		state.Role = types.StringNull()
 	}
 
+	// on_conflict is not stored in Player, so default it for imported users
+	state.OnConflict = onConflictOrDefault(state.OnConflict)
+
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setIDIdentity(ctx, resp.Identity, state.ID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *playerUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_user.Update", &resp.Diagnostics)
+	defer endSpan()
+
 	var data playerUserResourceModel
 
 	// Read Terraform plan data into the model
@@ -203,6 +297,15 @@ func (r *playerUserResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Build user struct
 	user := &structs.PlayerUser{
 		ID:   data.UserID.ValueString(),
@@ -227,10 +330,14 @@ func (r *playerUserResource) Update(ctx context.Context, req resource.UpdateRequ
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *playerUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_user.Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	var state playerUserResourceModel
 
 	// Read current state
@@ -239,20 +346,14 @@ func (r *playerUserResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	// Check if user exists
-	exists, err := api.UserExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking Player User Existence",
-			fmt.Sprintf("Could not verify if user %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If user doesn't exist, nothing to delete
-	if !exists {
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
 
 	// Delete user via API
 	if err := api.DeleteUser(ctx, r.client, state.ID.ValueString()); err != nil {
@@ -264,8 +365,9 @@ func (r *playerUserResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing user by ID, or by name with "name:<name>".
 func (r *playerUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the user ID as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, "user", func(ctx context.Context, name string) (string, error) {
+		return api.FindUserByName(ctx, r.client, name)
+	})
 }
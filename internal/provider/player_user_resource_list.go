@@ -0,0 +1,81 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &playerUserListResource{}
+
+// NewPlayerUserListResource is a helper function to simplify the provider implementation.
+func NewPlayerUserListResource() list.ListResource {
+	return &playerUserListResource{}
+}
+
+// playerUserListResource lists existing Player users for terraform query.
+type playerUserListResource struct {
+	listResourceClient
+}
+
+// playerUserListConfigModel describes the filters of the user list resource.
+type playerUserListConfigModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	TeamID     types.String `tfsdk:"team_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *playerUserListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_player_user"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *playerUserListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing Player users.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": namePrefixAttribute("user"),
+			"team_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list members of the team with this ID.",
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+		},
+	}
+}
+
+// List streams the users that match the filters.
+func (r *playerUserListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config playerUserListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if !requireKnownConfig(r.client, &diags) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, "crucible_player_user.List", "user", func(ctx context.Context, yield func(list.ListResult) error) error {
+		return api.ListUsers(ctx, r.client, config.TeamID.ValueString(), func(user structs.PlayerUser) error {
+			if !hasNamePrefix(user.Name, config.NamePrefix) {
+				return nil
+			}
+
+			return yield(newListResult(ctx, req, idIdentityModel{ID: types.StringValue(user.ID)}, user.Name, map[string]any{
+				"id":      user.ID,
+				"user_id": user.ID,
+				"name":    user.Name,
+			}))
+		})
+	})
+}
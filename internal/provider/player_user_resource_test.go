@@ -4,10 +4,18 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -94,3 +102,104 @@ resource "crucible_player_user" "test" {
 }
 `, userID, name, role)
 }
+
+// TestUpgradePlayerUserStateV0 verifies that SDK state with an unset role upgrades to null
+func TestUpgradePlayerUserStateV0(t *testing.T) {
+	var state playerUserResourceModel
+	upgradeStateFixture(t, &playerUserResource{}, 0, "player_user_v0_sdk.json", &state)
+
+	if state.UserID.ValueString() != "6fb5b293-668b-4eb6-b614-dfdd6b0e0acf" || state.Name.ValueString() != "jdoe" {
+		t.Errorf("Unexpected upgraded user: %+v", state)
+	}
+	if !state.Role.IsNull() {
+		t.Errorf("Expected role \"\" to upgrade to null, got %s", state.Role)
+	}
+	if state.OnConflict.ValueString() != onConflictError || !state.Timeouts.IsNull() {
+		t.Errorf("Expected defaults for attributes the SDK did not have, got %+v", state)
+	}
+}
+
+// TestPlayerUserCreate_OnConflict verifies that an existing user is adopted only
+// with on_conflict = "adopt", and keeps its role if the configuration sets none
+func TestPlayerUserCreate_OnConflict(t *testing.T) {
+	const userID = "550e8400-e29b-41d4-a716-446655440001"
+
+	tests := []struct {
+		name         string
+		onConflict   string
+		role         types.String
+		expectError  bool
+		expectRoleID string
+	}{
+		{"adopt keeps existing role", onConflictAdopt, types.StringNull(), false, "role-admin"},
+		{"adopt sets configured role", onConflictAdopt, types.StringValue("Member"), false, "role-member"},
+		{"error", onConflictError, types.StringNull(), true, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests []string
+			var updated map[string]interface{}
+			r := &playerUserResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+
+				switch req.Method + " " + req.URL.Path {
+				case "POST /api/users":
+					w.WriteHeader(http.StatusConflict)
+				case "GET /api/users/" + userID:
+					fmt.Fprintf(w, `{"id": %q, "name": "Old Name", "roleId": "role-admin"}`, userID)
+				case "GET /api/roles/role-admin":
+					fmt.Fprint(w, `{"id": "role-admin", "name": "Admin"}`)
+				case "GET /api/roles/name/Admin":
+					fmt.Fprint(w, `{"id": "role-admin", "name": "Admin"}`)
+				case "GET /api/roles/name/Member":
+					fmt.Fprint(w, `{"id": "role-member", "name": "Member"}`)
+				case "PUT /api/users/" + userID:
+					json.NewDecoder(req.Body).Decode(&updated)
+					fmt.Fprint(w, `{}`)
+				default:
+					t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})}
+
+			state := emptyState(ctx, r)
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+			plan.SetAttribute(ctx, path.Root("user_id"), userID)
+			plan.SetAttribute(ctx, path.Root("name"), "Test User")
+			plan.SetAttribute(ctx, path.Root("role"), test.role)
+			plan.SetAttribute(ctx, path.Root("on_conflict"), test.onConflict)
+
+			resp := &fwresource.CreateResponse{State: state}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			if test.expectError {
+				if !resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+					t.Errorf("Expected an error and no state, got: %v", resp.Diagnostics)
+				}
+				for _, request := range requests {
+					if !strings.HasPrefix(request, http.MethodPost) {
+						t.Errorf("Expected only the create request, got requests %v", requests)
+					}
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+			if updated["name"] != "Test User" || updated["roleId"] != test.expectRoleID {
+				t.Errorf("Expected the user to be updated with name %q and role %q, got %v", "Test User", test.expectRoleID, updated)
+			}
+
+			var id types.String
+			resp.State.GetAttribute(ctx, path.Root("id"), &id)
+			if id.ValueString() != userID {
+				t.Errorf("Expected id %s, got %s", userID, id)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+var (
+	_ resource.ResourceWithUpgradeState = &playerUserResource{}
+	_ resource.ResourceWithMoveState    = &playerUserResource{}
+)
+
+// UpgradeState upgrades state written by earlier versions of the user schema.
+func (r *playerUserResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradePlayerUserStateV0},
+	}
+}
+
+// MoveState accepts user state written under another
+// Crucible provider address or a former type name.
+func (r *playerUserResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromCrucible("crucible_player_user", formerTypeNames["crucible_player_user"], r.UpgradeState(ctx)),
+	}
+}
+
+// upgradePlayerUserStateV0 converts version 0 state to the current schema.
+// The SDK releases stored an unset role as "", which would otherwise plan a
+// change to remove it.
+func upgradePlayerUserStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	prior := newPriorState(req.RawState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := playerUserResourceModel{
+		ID:         prior.String("id"),
+		UserID:     prior.String("user_id"),
+		Name:       prior.String("name"),
+		Role:       prior.String("role"),
+		OnConflict: onConflictOrDefault(prior.String("on_conflict")),
+		Timeouts:   prior.Timeouts("create", "read", "update", "delete"),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
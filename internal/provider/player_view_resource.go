@@ -5,20 +5,26 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -27,6 +33,7 @@ var (
 	_ resource.Resource                = &viewResource{}
 	_ resource.ResourceWithConfigure   = &viewResource{}
 	_ resource.ResourceWithImportState = &viewResource{}
+	_ resource.ResourceWithIdentity    = &viewResource{}
 )
 
 // NewViewResource is a helper function to simplify the provider implementation.
@@ -41,19 +48,28 @@ type viewResource struct {
 
 // viewResourceModel describes the resource data model.
 type viewResourceModel struct {
-	ID              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	Status          types.String `tfsdk:"status"`
-	CreateAdminTeam types.Bool   `tfsdk:"create_admin_team"`
-	Applications    types.List   `tfsdk:"application"`
-	Teams           types.List   `tfsdk:"team"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Status             types.String   `tfsdk:"status"`
+	CreateAdminTeam    types.Bool     `tfsdk:"create_admin_team"`
+	Applications       types.Map      `tfsdk:"applications"`
+	Teams              types.Map      `tfsdk:"teams"`
+	OnCreateFailure    types.String   `tfsdk:"on_create_failure"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ProtectActive      types.Bool     `tfsdk:"protect_active"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
-// applicationModel describes an application within a view.
+// Values of on_create_failure
+const (
+	onCreateFailureTaint    = "taint"
+	onCreateFailureRollback = "rollback"
+)
+
+// applicationModel describes an application within a view, keyed by its name.
 type applicationModel struct {
 	AppID            types.String `tfsdk:"app_id"`
-	Name             types.String `tfsdk:"name"`
 	URL              types.String `tfsdk:"url"`
 	Icon             types.String `tfsdk:"icon"`
 	Embeddable       types.Bool   `tfsdk:"embeddable"`
@@ -62,12 +78,11 @@ type applicationModel struct {
 	AppTemplateID    types.String `tfsdk:"app_template_id"`
 }
 
-// teamModel describes a team within a view.
+// teamModel describes a team within a view, keyed by its name.
 type teamModel struct {
 	TeamID       types.String `tfsdk:"team_id"`
-	Name         types.String `tfsdk:"name"`
 	Role         types.String `tfsdk:"role"`
-	Permissions  types.List   `tfsdk:"permissions"`
+	Permissions  types.Set    `tfsdk:"permissions"`
 	Users        types.List   `tfsdk:"user"`
 	AppInstances types.List   `tfsdk:"app_instance"`
 }
@@ -91,9 +106,10 @@ func (r *viewResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 // Schema defines the schema for the resource.
-func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *viewResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Player view in Crucible. Views contain teams, applications, and define the structure of an exercise environment.",
+		Version:     2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -114,7 +130,10 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("Active"),
-				Description: "The status of the view (Active, Inactive, etc.).",
+				Description: "The status of the view (Active or Inactive).",
+				Validators: []validator.String{
+					validators.ViewStatus(),
+				},
 			},
 			"create_admin_team": schema.BoolAttribute{
 				Optional:    true,
@@ -122,9 +141,28 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Default:     booldefault.StaticBool(true),
 				Description: "Whether to automatically create an admin team for this view.",
 			},
-			"application": schema.ListNestedAttribute{
+			"on_create_failure": schema.StringAttribute{
 				Optional:    true,
-				Description: "List of applications available in this view.",
+				Computed:    true,
+				Default:     stringdefault.StaticString(onCreateFailureTaint),
+				Description: "What to do if the view is created but its applications or teams are not. \"taint\" records the partially created view in state so it is replaced on the next apply; \"rollback\" deletes the view. Defaults to \"taint\".",
+				Validators: []validator.String{
+					stringvalidator.OneOf(onCreateFailureTaint, onCreateFailureRollback),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute("view"),
+			"protect_active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether Terraform is prevented from deleting this view while its status in Player is Active. Defaults to false.",
+			},
+			"applications": schema.MapNestedAttribute{
+				Optional:    true,
+				Description: "Applications available in this view, keyed by application name. Changing applications replaces the view.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"app_id": schema.StringAttribute{
@@ -134,13 +172,12 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
-						"name": schema.StringAttribute{
-							Required:    true,
-							Description: "The name of the application.",
-						},
 						"url": schema.StringAttribute{
 							Optional:    true,
 							Description: "The URL of the application.",
+							Validators: []validator.String{
+								validators.AbsoluteURL(),
+							},
 						},
 						"icon": schema.StringAttribute{
 							Optional:    true,
@@ -157,6 +194,9 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 						"app_template_id": schema.StringAttribute{
 							Optional:    true,
 							Description: "Optional template ID to base this application on.",
+							Validators: []validator.String{
+								validators.UUID(),
+							},
 						},
 						"v_id": schema.StringAttribute{
 							Computed:    true,
@@ -168,9 +208,12 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
-			"team": schema.ListNestedAttribute{
+			"teams": schema.MapNestedAttribute{
 				Optional:    true,
-				Description: "List of teams within this view. Each team can have users and application instances.",
+				Description: "Teams within this view, keyed by team name. Each team can have users and application instances. Changing teams replaces the view.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"team_id": schema.StringAttribute{
@@ -180,20 +223,19 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 								stringplanmodifier.UseStateForUnknown(),
 							},
 						},
-						"name": schema.StringAttribute{
-							Required:    true,
-							Description: "The name of the team.",
-						},
 						"role": schema.StringAttribute{
 							Optional:    true,
 							Computed:    true,
 							Default:     stringdefault.StaticString("View Member"),
 							Description: "The default role for members of this team.",
+							Validators: []validator.String{
+								validators.RoleName(),
+							},
 						},
-						"permissions": schema.ListAttribute{
+						"permissions": schema.SetAttribute{
 							ElementType: types.StringType,
 							Optional:    true,
-							Description: "List of permission names granted to this team.",
+							Description: "Set of permission names granted to this team.",
 						},
 						"user": schema.ListNestedAttribute{
 							Optional:    true,
@@ -203,10 +245,16 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 									"user_id": schema.StringAttribute{
 										Required:    true,
 										Description: "UUID of the user.",
+										Validators: []validator.String{
+											validators.UUID(),
+										},
 									},
 									"role": schema.StringAttribute{
 										Optional:    true,
 										Description: "Role for this user within the team (overrides team default role).",
+										Validators: []validator.String{
+											validators.RoleName(),
+										},
 									},
 								},
 							},
@@ -238,9 +286,22 @@ func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
+// IdentitySchema defines the identity of a view, its ID.
+func (r *viewResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("view")
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *viewResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -261,6 +322,9 @@ func (r *viewResource) Configure(_ context.Context, req resource.ConfigureReques
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_view.Create", &resp.Diagnostics)
+	defer endSpan()
+
 	var data viewResourceModel
 
 	// Read Terraform plan data into the model
@@ -269,6 +333,29 @@ func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	// Extract applications and teams before creating the view, so that a
+	// decoding error cannot leave a view behind that is not in state
+	var apps map[string]applicationModel
+	if !data.Applications.IsNull() && !data.Applications.IsUnknown() {
+		resp.Diagnostics.Append(data.Applications.ElementsAs(ctx, &apps, false)...)
+	}
+	var teams map[string]teamModel
+	if !data.Teams.IsNull() && !data.Teams.IsUnknown() {
+		resp.Diagnostics.Append(data.Teams.ElementsAs(ctx, &teams, false)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build view struct
 	viewInfo := &structs.ViewInfo{
 		Name:            data.Name.ValueString(),
@@ -289,63 +376,102 @@ func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	data.ID = types.StringValue(viewID)
 
-	// Extract and create applications
-	if !data.Applications.IsNull() && !data.Applications.IsUnknown() {
-		var apps []applicationModel
-		resp.Diagnostics.Append(data.Applications.ElementsAs(ctx, &apps, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
+	// Create applications
+	if apps != nil {
 		if err := r.createApplications(ctx, viewID, apps, &data, resp); err != nil {
 			resp.Diagnostics.AddError("Error Creating Applications", err.Error())
+			r.handleCreateFailure(ctx, &data, false, resp)
 			return
 		}
 	}
 
-	// Extract and create teams
-	if !data.Teams.IsNull() && !data.Teams.IsUnknown() {
-		var teams []teamModel
-		resp.Diagnostics.Append(data.Teams.ElementsAs(ctx, &teams, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
+	// Create teams
+	if teams != nil {
 		if err := r.createTeams(ctx, viewID, teams, &data, resp); err != nil {
 			resp.Diagnostics.AddError("Error Creating Teams", err.Error())
+			r.handleCreateFailure(ctx, &data, true, resp)
 			return
 		}
 	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
+}
+
+// handleCreateFailure applies on_create_failure after the view was created but
+// its applications or teams were not. With "rollback" the view, and everything
+// created in it, is deleted. Otherwise, or if the rollback fails, the view is
+// saved to state alongside the error so Terraform marks it as tainted and
+// replaces it on the next apply instead of creating a duplicate. Its
+// deletion_protection and protect_active are saved as false so that they do
+// not block the replacement.
+func (r *viewResource) handleCreateFailure(ctx context.Context, data *viewResourceModel, appsCreated bool, resp *resource.CreateResponse) {
+	viewID := data.ID.ValueString()
+
+	if data.OnCreateFailure.ValueString() == onCreateFailureRollback {
+		// Roll back even if the create timeout has expired
+		deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+		resp.Diagnostics.Append(diags...)
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deleteTimeout)
+		defer cancel()
+
+		err := api.DeleteView(ctx, r.client, viewID)
+		if err == nil {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Rolling Back View",
+			fmt.Sprintf("Could not delete partially created view %s: %s. The view has been saved to state and will be replaced on the next apply.", viewID, err.Error()),
+		)
+	}
+
+	// The guards would keep Terraform from replacing the tainted view, so they
+	// are cleared in state. The replacement is created with the configured values.
+	if data.DeletionProtection.ValueBool() || data.ProtectActive.ValueBool() {
+		resp.Diagnostics.AddWarning(
+			"Deletion Protection Not Applied",
+			fmt.Sprintf("deletion_protection and protect_active are not applied to partially created view %s, so that Terraform can replace it.", viewID),
+		)
+	}
+	data.DeletionProtection = types.BoolValue(false)
+	data.ProtectActive = types.BoolValue(false)
+
+	// Maps still holding planned values contain unknown IDs, which cannot be
+	// saved to state
+	if !appsCreated {
+		data.Applications = types.MapNull(types.ObjectType{AttrTypes: applicationAttrTypes()})
+	}
+	data.Teams = types.MapNull(types.ObjectType{AttrTypes: teamAttrTypes()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
 }
 
 // createApplications handles creating applications within a view.
-func (r *viewResource) createApplications(ctx context.Context, viewID string, apps []applicationModel, data *viewResourceModel, resp *resource.CreateResponse) error {
-	appStructs := make([]structs.AppInfo, len(apps))
+func (r *viewResource) createApplications(ctx context.Context, viewID string, apps map[string]applicationModel, data *viewResourceModel, resp *resource.CreateResponse) error {
+	names := sortedKeys(apps)
+	appStructs := make([]structs.AppInfo, len(names))
 
-	for i, app := range apps {
+	for i, name := range names {
+		app := apps[name]
 		appStructs[i] = structs.AppInfo{
-			Name:   app.Name.ValueString(),
-			ViewID: viewID,
+			Name:             &name,
+			ViewID:           viewID,
+			Embeddable:       app.Embeddable.ValueBoolPointer(),
+			LoadInBackground: app.LoadInBackground.ValueBoolPointer(),
 		}
 
-		// Handle optional fields with interface{} for API compatibility
-		if !app.URL.IsNull() && app.URL.ValueString() != "" {
-			appStructs[i].URL = app.URL.ValueString()
-		}
-		if !app.Icon.IsNull() && app.Icon.ValueString() != "" {
-			appStructs[i].Icon = app.Icon.ValueString()
+		// Leave empty optional strings unset so the API applies its defaults
+		if app.URL.ValueString() != "" {
+			appStructs[i].URL = app.URL.ValueStringPointer()
 		}
-		if !app.Embeddable.IsNull() {
-			appStructs[i].Embeddable = app.Embeddable.ValueBool()
+		if app.Icon.ValueString() != "" {
+			appStructs[i].Icon = app.Icon.ValueStringPointer()
 		}
-		if !app.LoadInBackground.IsNull() {
-			appStructs[i].LoadInBackground = app.LoadInBackground.ValueBool()
-		}
-		if !app.AppTemplateID.IsNull() && app.AppTemplateID.ValueString() != "" {
-			appStructs[i].AppTemplateID = app.AppTemplateID.ValueString()
+		if app.AppTemplateID.ValueString() != "" {
+			appStructs[i].AppTemplateID = app.AppTemplateID.ValueStringPointer()
 		}
 	}
 
@@ -355,39 +481,33 @@ func (r *viewResource) createApplications(ctx context.Context, viewID string, ap
 	}
 
 	// Update models with computed IDs
-	for i := range apps {
-		apps[i].AppID = types.StringValue(appStructs[i].ID)
-		apps[i].ViewID = types.StringValue(viewID)
-	}
-
-	// Convert back to list
-	appValues := make([]attr.Value, len(apps))
-	for i, app := range apps {
-		appObj, diags := types.ObjectValueFrom(ctx, applicationAttrTypes(), app)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return fmt.Errorf("failed to convert application to object")
-		}
-		appValues[i] = appObj
+	for i, name := range names {
+		app := apps[name]
+		app.AppID = types.StringValue(appStructs[i].ID)
+		app.ViewID = types.StringValue(viewID)
+		apps[name] = app
 	}
 
-	appList, diags := types.ListValue(types.ObjectType{AttrTypes: applicationAttrTypes()}, appValues)
+	// Convert back to map
+	appMap, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: applicationAttrTypes()}, apps)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		return fmt.Errorf("failed to create application list")
+		return fmt.Errorf("failed to create application map")
 	}
 
-	data.Applications = appList
+	data.Applications = appMap
 	return nil
 }
 
 // createTeams handles creating teams within a view.
-func (r *viewResource) createTeams(ctx context.Context, viewID string, teams []teamModel, data *viewResourceModel, resp *resource.CreateResponse) error {
-	teamStructs := make([]structs.TeamInfo, len(teams))
+func (r *viewResource) createTeams(ctx context.Context, viewID string, teams map[string]teamModel, data *viewResourceModel, resp *resource.CreateResponse) error {
+	names := sortedKeys(teams)
+	teamStructs := make([]structs.TeamInfo, len(names))
 
-	for i, team := range teams {
+	for i, name := range names {
+		team := teams[name]
 		teamStructs[i] = structs.TeamInfo{
-			Name: team.Name.ValueString(),
+			Name: name,
 			Role: team.Role.ValueString(),
 		}
 
@@ -448,62 +568,47 @@ func (r *viewResource) createTeams(ctx context.Context, viewID string, teams []t
 		return fmt.Errorf("failed to add permissions to teams: %w", err)
 	}
 
-	// Update models with computed IDs and sort
-	sort.Slice(teamStructs, func(i, j int) bool {
-		return teamStructs[i].Name.(string) < teamStructs[j].Name.(string)
-	})
-
-	for i := range teams {
+	// Update models with computed IDs. The API fills in IDs in place, so
+	// users and app instances are still in configuration order.
+	for i, name := range names {
+		team := teams[name]
 		if teamIDStr, ok := teamStructs[i].ID.(string); ok {
-			teams[i].TeamID = types.StringValue(teamIDStr)
-		}
-
-		// Sort users by user_id
-		if len(teamStructs[i].Users) > 0 {
-			sort.Slice(teamStructs[i].Users, func(a, b int) bool {
-				return teamStructs[i].Users[a].ID < teamStructs[i].Users[b].ID
-			})
+			team.TeamID = types.StringValue(teamIDStr)
 		}
 
-		// Sort app instances by name
 		if len(teamStructs[i].AppInstances) > 0 {
-			sort.Slice(teamStructs[i].AppInstances, func(a, b int) bool {
-				return teamStructs[i].AppInstances[a].Name < teamStructs[i].AppInstances[b].Name
-			})
-
-			// Update app instance IDs
 			var instances []appInstanceModel
-			resp.Diagnostics.Append(teams[i].AppInstances.ElementsAs(ctx, &instances, false)...)
+			resp.Diagnostics.Append(team.AppInstances.ElementsAs(ctx, &instances, false)...)
 			for j := range instances {
 				instances[j].ID = types.StringValue(teamStructs[i].AppInstances[j].ID)
 			}
-			teams[i].AppInstances, _ = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: appInstanceAttrTypes()}, instances)
+			team.AppInstances, _ = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: appInstanceAttrTypes()}, instances)
 		}
-	}
 
-	// Convert teams back to list
-	teamValues := make([]attr.Value, len(teams))
-	for i, team := range teams {
-		teamObj, diags := types.ObjectValueFrom(ctx, teamAttrTypes(), team)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return fmt.Errorf("failed to convert team to object")
-		}
-		teamValues[i] = teamObj
+		teams[name] = team
 	}
 
-	teamList, diags := types.ListValue(types.ObjectType{AttrTypes: teamAttrTypes()}, teamValues)
+	// Convert teams back to map
+	teamMap, diags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: teamAttrTypes()}, teams)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
-		return fmt.Errorf("failed to create team list")
+		return fmt.Errorf("failed to create team map")
 	}
 
-	data.Teams = teamList
+	data.Teams = teamMap
 	return nil
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_view.Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Keep the prior state until the provider configuration is known
+	if !r.client.ConfigKnown() {
+		return
+	}
+
 	var state viewResourceModel
 
 	// Read current state
@@ -512,25 +617,24 @@ func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// Check if view exists
-	exists, err := api.ViewExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking View Existence",
-			fmt.Sprintf("Could not verify if view %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If view doesn't exist, remove from state
-	if !exists {
-		resp.State.RemoveResource(ctx)
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Read view from API
 	viewInfo, err := api.ReadView(ctx, r.client, state.ID.ValueString())
 	if err != nil {
+		// Remove from state if the view was deleted outside of Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading View",
 			fmt.Sprintf("Could not read view %s: %s", state.ID.ValueString(), err.Error()),
@@ -543,15 +647,26 @@ func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	state.Description = types.StringValue(viewInfo.Description)
 	state.Status = types.StringValue(viewInfo.Status)
 
+	// on_create_failure is not stored in Player, so default it for imported views
+	if state.OnCreateFailure.IsNull() {
+		state.OnCreateFailure = types.StringValue(onCreateFailureTaint)
+	}
+	state.DeletionProtection = boolOrDefault(state.DeletionProtection, false)
+	state.ProtectActive = boolOrDefault(state.ProtectActive, false)
+
 	// Note: We don't read back applications and teams here to avoid complexity
 	// The Create function sets them correctly, and Update handles changes
 
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setIDIdentity(ctx, resp.Identity, state.ID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_view.Update", &resp.Diagnostics)
+	defer endSpan()
+
 	var plan, state viewResourceModel
 
 	// Read both plan and current state
@@ -561,6 +676,15 @@ func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Update view metadata if changed
 	if !plan.Name.Equal(state.Name) || !plan.Description.Equal(state.Description) || !plan.Status.Equal(state.Status) {
 		viewInfo := &structs.ViewInfo{
@@ -578,33 +702,18 @@ func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		}
 	}
 
-	// Handle application changes
-	if !plan.Applications.Equal(state.Applications) {
-		// For simplicity in this initial implementation, we'll rely on the API
-		// to handle application updates. A more sophisticated implementation
-		// would do differential updates.
-		var planApps []applicationModel
-		resp.Diagnostics.Append(plan.Applications.ElementsAs(ctx, &planApps, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		// TODO: Implement differential application updates
-		// For now, log a warning that full recreation may be needed
-	}
-
-	// Handle team changes
-	if !plan.Teams.Equal(state.Teams) {
-		// Similar to applications, team differential updates would go here
-		// For initial implementation, this is a simplified version
-	}
+	// Applications and teams cannot change here: changing them replaces the view
 
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setIDIdentity(ctx, resp.Identity, plan.ID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_view.Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	var state viewResourceModel
 
 	// Read current state
@@ -613,21 +722,44 @@ func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	// Check if view exists
-	exists, err := api.ViewExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking View Existence",
-			fmt.Sprintf("Could not verify if view %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If view doesn't exist, nothing to delete
-	if !exists {
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectedError(&resp.Diagnostics, "view", state.ID.ValueString())
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	// Check the live status rather than state, which may not have been refreshed
+	if state.ProtectActive.ValueBool() {
+		viewInfo, err := api.ReadView(ctx, r.client, state.ID.ValueString())
+		if errors.Is(err, client.ErrNotFound) {
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading View",
+				fmt.Sprintf("Could not read status of view %s before deleting it: %s", state.ID.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		if viewInfo.Status == "Active" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("protect_active"),
+				"View Is Active",
+				fmt.Sprintf("Cannot delete view %s because its status is Active and protect_active is true. Set its status to Inactive, or set protect_active = false, and apply before destroying it.", state.ID.ValueString()),
+			)
+			return
+		}
+	}
+
 	// Delete view via API
 	if err := api.DeleteView(ctx, r.client, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
@@ -638,33 +770,32 @@ func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing view by ID, or by name with "name:<name>".
 func (r *viewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the view ID as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, "view", func(ctx context.Context, name string) (string, error) {
+		return api.FindViewByName(ctx, r.client, name)
+	})
 }
 
 // Helper functions for nested attribute types
 
 func applicationAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"app_id":              types.StringType,
-		"name":                types.StringType,
-		"url":                 types.StringType,
-		"icon":                types.StringType,
-		"embeddable":          types.BoolType,
-		"load_in_background":  types.BoolType,
-		"v_id":                types.StringType,
-		"app_template_id":     types.StringType,
+		"app_id":             types.StringType,
+		"url":                types.StringType,
+		"icon":               types.StringType,
+		"embeddable":         types.BoolType,
+		"load_in_background": types.BoolType,
+		"v_id":               types.StringType,
+		"app_template_id":    types.StringType,
 	}
 }
 
 func teamAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"team_id": types.StringType,
-		"name":    types.StringType,
 		"role":    types.StringType,
-		"permissions": types.ListType{
+		"permissions": types.SetType{
 			ElemType: types.StringType,
 		},
 		"user": types.ListType{
@@ -694,3 +825,14 @@ func appInstanceAttrTypes() map[string]attr.Type {
 		"display_order": types.Float64Type,
 	}
 }
+
+// sortedKeys returns the keys of m in sorted order, so that map-keyed
+// applications and teams are created in a stable order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
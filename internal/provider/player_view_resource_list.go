@@ -0,0 +1,83 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &viewListResource{}
+
+// NewViewListResource is a helper function to simplify the provider implementation.
+func NewViewListResource() list.ListResource {
+	return &viewListResource{}
+}
+
+// viewListResource lists existing views for terraform query.
+type viewListResource struct {
+	listResourceClient
+}
+
+// viewListConfigModel describes the filters of the view list resource.
+type viewListConfigModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	Status     types.String `tfsdk:"status"`
+}
+
+// Metadata returns the resource type name.
+func (r *viewListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_player_view"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *viewListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing Player views.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": namePrefixAttribute("view"),
+			"status": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list views with this status (Active or Inactive).",
+				Validators: []validator.String{
+					validators.ViewStatus(),
+				},
+			},
+		},
+	}
+}
+
+// List streams the views that match the filters.
+func (r *viewListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config viewListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if !requireKnownConfig(r.client, &diags) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, "crucible_player_view.List", "view", func(ctx context.Context, yield func(list.ListResult) error) error {
+		return api.ListViews(ctx, r.client, func(view structs.ViewInfo) error {
+			if !hasNamePrefix(view.Name, config.NamePrefix) ||
+				(!config.Status.IsNull() && view.Status != config.Status.ValueString()) {
+				return nil
+			}
+
+			return yield(newListResult(ctx, req, idIdentityModel{ID: types.StringValue(view.ID)}, view.Name, map[string]any{
+				"id":          view.ID,
+				"name":        view.Name,
+				"description": view.Description,
+				"status":      view.Status,
+			}))
+		})
+	})
+}
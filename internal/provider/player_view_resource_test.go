@@ -4,9 +4,18 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -30,6 +39,13 @@ func TestAccViewResource_Basic(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			// ImportState by name testing
+			{
+				ResourceName:      "crucible_player_view.test",
+				ImportState:       true,
+				ImportStateId:     "name:Test View",
+				ImportStateVerify: true,
+			},
 			// Update testing
 			{
 				Config: testAccViewResourceConfigBasic("Updated View", "Updated description"),
@@ -50,11 +66,10 @@ func TestAccViewResource_WithApplications(t *testing.T) {
 			{
 				Config: testAccViewResourceConfigWithApps("View With Apps"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.#", "2"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.0.name", "App One"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.0.embeddable", "true"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.1.name", "App Two"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.1.load_in_background", "true"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "applications.%", "2"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "applications.App One.embeddable", "true"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "applications.App Two.load_in_background", "true"),
+					resource.TestCheckResourceAttrSet("crucible_player_view.test", "applications.App One.app_id"),
 				),
 			},
 		},
@@ -69,9 +84,8 @@ func TestAccViewResource_WithTeams(t *testing.T) {
 			{
 				Config: testAccViewResourceConfigWithTeams("View With Teams"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.#", "1"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.0.name", "Test Team"),
-					resource.TestCheckResourceAttrSet("crucible_player_view.test", "team.0.team_id"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.%", "1"),
+					resource.TestCheckResourceAttrSet("crucible_player_view.test", "teams.Test Team.team_id"),
 				),
 			},
 		},
@@ -90,18 +104,18 @@ func TestAccViewResource_Complete(t *testing.T) {
 					resource.TestCheckResourceAttr("crucible_player_view.test", "name", "Complete View"),
 					resource.TestCheckResourceAttr("crucible_player_view.test", "status", "Active"),
 					resource.TestCheckResourceAttr("crucible_player_view.test", "create_admin_team", "true"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "application.#", "1"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.#", "1"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.0.user.#", "1"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.0.app_instance.#", "1"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "applications.%", "1"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.%", "1"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.Test Team.user.#", "1"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.Test Team.app_instance.#", "1"),
 				),
 			},
 			// Update nested resources
 			{
 				Config: testAccViewResourceConfigCompleteUpdated("Complete View"),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.0.user.#", "2"),
-					resource.TestCheckResourceAttr("crucible_player_view.test", "team.0.app_instance.#", "1"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.Test Team.user.#", "2"),
+					resource.TestCheckResourceAttr("crucible_player_view.test", "teams.Test Team.app_instance.#", "1"),
 				),
 			},
 		},
@@ -128,18 +142,17 @@ resource "crucible_player_view" "test" {
   name   = %[1]q
   status = "Active"
 
-  application {
-    name               = "App One"
-    url                = "https://app1.example.com"
-    embeddable         = true
-    load_in_background = false
-  }
-
-  application {
-    name               = "App Two"
-    url                = "https://app2.example.com"
-    embeddable         = false
-    load_in_background = true
+  applications = {
+    "App One" = {
+      url                = "https://app1.example.com"
+      embeddable         = true
+      load_in_background = false
+    }
+    "App Two" = {
+      url                = "https://app2.example.com"
+      embeddable         = false
+      load_in_background = true
+    }
   }
 }
 `, name)
@@ -153,9 +166,10 @@ resource "crucible_player_view" "test" {
   name   = %[1]q
   status = "Active"
 
-  team {
-    name = "Test Team"
-    role = "Member"
+  teams = {
+    "Test Team" = {
+      role = "Member"
+    }
   }
 }
 `, name)
@@ -176,26 +190,32 @@ resource "crucible_player_view" "test" {
   status            = "Active"
   create_admin_team = true
 
-  application {
-    name               = "Test App"
-    url                = "https://testapp.example.com"
-    icon               = "mdi-test"
-    embeddable         = true
-    load_in_background = false
-  }
-
-  team {
-    name = "Test Team"
-    role = "Member"
-
-    user {
-      user_id = crucible_player_user.test_user.user_id
-      role    = "Member"
+  applications = {
+    "Test App" = {
+      url                = "https://testapp.example.com"
+      icon               = "mdi-test"
+      embeddable         = true
+      load_in_background = false
     }
+  }
 
-    app_instance {
-      name          = "Test App"
-      display_order = 0
+  teams = {
+    "Test Team" = {
+      role = "Member"
+
+      user = [
+        {
+          user_id = crucible_player_user.test_user.user_id
+          role    = "Member"
+        },
+      ]
+
+      app_instance = [
+        {
+          name          = "Test App"
+          display_order = 0
+        },
+      ]
     }
   }
 }
@@ -222,33 +242,466 @@ resource "crucible_player_view" "test" {
   status            = "Active"
   create_admin_team = true
 
-  application {
-    name               = "Test App"
-    url                = "https://testapp.example.com"
-    icon               = "mdi-test"
-    embeddable         = true
-    load_in_background = false
-  }
-
-  team {
-    name = "Test Team"
-    role = "Member"
-
-    user {
-      user_id = crucible_player_user.test_user.user_id
-      role    = "Member"
-    }
-
-    user {
-      user_id = crucible_player_user.test_user2.user_id
-      role    = "Observer"
+  applications = {
+    "Test App" = {
+      url                = "https://testapp.example.com"
+      icon               = "mdi-test"
+      embeddable         = true
+      load_in_background = false
     }
+  }
 
-    app_instance {
-      name          = "Test App"
-      display_order = 0
+  teams = {
+    "Test Team" = {
+      role = "Member"
+
+      user = [
+        {
+          user_id = crucible_player_user.test_user.user_id
+          role    = "Member"
+        },
+        {
+          user_id = crucible_player_user.test_user2.user_id
+          role    = "Observer"
+        },
+      ]
+
+      app_instance = [
+        {
+          name          = "Test App"
+          display_order = 0
+        },
+      ]
     }
   }
 }
 `, name)
 }
+
+// TestUpgradeApplicationsV0 verifies that version 0 application lists are keyed by name
+func TestUpgradeApplicationsV0(t *testing.T) {
+	ctx := context.Background()
+	elemType := types.ObjectType{AttrTypes: viewSchemaV0(ctx).Attributes["application"].GetType().(types.ListType).ElemType.(types.ObjectType).AttrTypes}
+
+	prior, diags := types.ListValueFrom(ctx, elemType, []applicationModelV0{
+		{AppID: types.StringValue("app-2"), Name: types.StringValue("App Two"), Embeddable: types.BoolValue(false)},
+		{AppID: types.StringValue("app-1"), Name: types.StringValue("App One"), Embeddable: types.BoolValue(true)},
+	})
+	if diags.HasError() {
+		t.Fatalf("Failed to build prior state: %v", diags)
+	}
+
+	upgraded, diags := upgradeApplicationsV0(ctx, prior)
+	if diags.HasError() {
+		t.Fatalf("upgradeApplicationsV0 returned errors: %v", diags)
+	}
+
+	var apps map[string]applicationModel
+	if diags := upgraded.ElementsAs(ctx, &apps, false); diags.HasError() {
+		t.Fatalf("Failed to read upgraded applications: %v", diags)
+	}
+
+	if len(apps) != 2 {
+		t.Fatalf("Expected 2 applications, got %d", len(apps))
+	}
+	if apps["App One"].AppID.ValueString() != "app-1" || !apps["App One"].Embeddable.ValueBool() {
+		t.Errorf("Unexpected upgraded application: %+v", apps["App One"])
+	}
+	if apps["App Two"].AppID.ValueString() != "app-2" {
+		t.Errorf("Unexpected upgraded application: %+v", apps["App Two"])
+	}
+
+	if null, diags := upgradeApplicationsV0(ctx, types.ListNull(elemType)); diags.HasError() || !null.IsNull() {
+		t.Errorf("Expected a null list to upgrade to a null map, got %v (%v)", null, diags)
+	}
+}
+
+// TestUpgradeTeamsV0_DuplicateNames verifies that teams which cannot be keyed by name are reported
+func TestUpgradeTeamsV0_DuplicateNames(t *testing.T) {
+	ctx := context.Background()
+	elemType := types.ObjectType{AttrTypes: viewSchemaV0(ctx).Attributes["team"].GetType().(types.ListType).ElemType.(types.ObjectType).AttrTypes}
+
+	team := func(id string) teamModelV0 {
+		return teamModelV0{
+			TeamID:       types.StringValue(id),
+			Name:         types.StringValue("Blue"),
+			Role:         types.StringValue("View Member"),
+			Permissions:  types.ListNull(types.StringType),
+			Users:        types.ListNull(types.ObjectType{AttrTypes: userInfoAttrTypes()}),
+			AppInstances: types.ListNull(types.ObjectType{AttrTypes: appInstanceAttrTypes()}),
+		}
+	}
+
+	prior, diags := types.ListValueFrom(ctx, elemType, []teamModelV0{team("team-1"), team("team-2")})
+	if diags.HasError() {
+		t.Fatalf("Failed to build prior state: %v", diags)
+	}
+
+	if _, diags := upgradeTeamsV0(ctx, prior); !diags.HasError() {
+		t.Error("Expected an error for duplicate team names")
+	}
+}
+
+// TestUpgradeTeamsV1_Permissions verifies that version 1 permission lists become sets without duplicates
+func TestUpgradeTeamsV1_Permissions(t *testing.T) {
+	ctx := context.Background()
+	elemType := types.ObjectType{AttrTypes: viewSchemaV1(ctx).Attributes["teams"].GetType().(types.MapType).ElemType.(types.ObjectType).AttrTypes}
+
+	permissions, diags := types.ListValueFrom(ctx, types.StringType, []string{"ViewAdmin", "EditView", "ViewAdmin"})
+	if diags.HasError() {
+		t.Fatalf("Failed to build permissions: %v", diags)
+	}
+
+	prior, diags := types.MapValueFrom(ctx, elemType, map[string]teamModelV1{
+		"Blue": {
+			TeamID:       types.StringValue("team-1"),
+			Role:         types.StringValue("View Member"),
+			Permissions:  permissions,
+			Users:        types.ListNull(types.ObjectType{AttrTypes: userInfoAttrTypes()}),
+			AppInstances: types.ListNull(types.ObjectType{AttrTypes: appInstanceAttrTypes()}),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("Failed to build prior state: %v", diags)
+	}
+
+	upgraded, diags := upgradeTeamsV1(ctx, prior)
+	if diags.HasError() {
+		t.Fatalf("upgradeTeamsV1 returned errors: %v", diags)
+	}
+
+	var teams map[string]teamModel
+	if diags := upgraded.ElementsAs(ctx, &teams, false); diags.HasError() {
+		t.Fatalf("Failed to read upgraded teams: %v", diags)
+	}
+
+	if got := len(teams["Blue"].Permissions.Elements()); got != 2 {
+		t.Errorf("Expected 2 unique permissions, got %d", got)
+	}
+	if teams["Blue"].TeamID.ValueString() != "team-1" {
+		t.Errorf("Unexpected upgraded team: %+v", teams["Blue"])
+	}
+}
+
+// TestUpgradeViewStateV0_SDK verifies that SDK view state with string booleans upgrades to maps
+func TestUpgradeViewStateV0_SDK(t *testing.T) {
+	ctx := context.Background()
+	var state viewResourceModel
+	upgradeStateFixture(t, &viewResource{}, 0, "player_view_v0_sdk.json", &state)
+
+	var apps map[string]applicationModel
+	if diags := state.Applications.ElementsAs(ctx, &apps, false); diags.HasError() {
+		t.Fatalf("Failed to read upgraded applications: %v", diags)
+	}
+	app, ok := apps["testApp"]
+	if !ok || app.Embeddable.ValueBool() || !app.LoadInBackground.ValueBool() || !app.Icon.IsNull() || !app.AppTemplateID.IsNull() {
+		t.Errorf("Unexpected upgraded application: %+v", apps)
+	}
+
+	var teams map[string]teamModel
+	if diags := state.Teams.ElementsAs(ctx, &teams, false); diags.HasError() {
+		t.Fatalf("Failed to read upgraded teams: %v", diags)
+	}
+	team, ok := teams["test_team"]
+	if !ok || team.TeamID.ValueString() != "a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d" || !team.Permissions.IsNull() {
+		t.Fatalf("Unexpected upgraded team: %+v", teams)
+	}
+
+	var users []userInfoModel
+	if diags := team.Users.ElementsAs(ctx, &users, false); diags.HasError() || len(users) != 1 || !users[0].Role.IsNull() {
+		t.Errorf("Unexpected upgraded users: %+v (%v)", users, diags)
+	}
+	var instances []appInstanceModel
+	if diags := team.AppInstances.ElementsAs(ctx, &instances, false); diags.HasError() || len(instances) != 1 || instances[0].DisplayOrder.ValueFloat64() != 0 {
+		t.Errorf("Unexpected upgraded app instances: %+v (%v)", instances, diags)
+	}
+
+	if state.OnCreateFailure.ValueString() != onCreateFailureTaint || state.DeletionProtection.ValueBool() || state.ProtectActive.ValueBool() {
+		t.Errorf("Expected defaults for attributes the SDK did not have, got %+v", state)
+	}
+}
+
+// TestUpgradeViewStateV0_Framework verifies that framework view state keeps its settings when upgraded
+func TestUpgradeViewStateV0_Framework(t *testing.T) {
+	ctx := context.Background()
+	var state viewResourceModel
+	upgradeStateFixture(t, &viewResource{}, 0, "player_view_v0.json", &state)
+
+	var teams map[string]teamModel
+	if diags := state.Teams.ElementsAs(ctx, &teams, false); diags.HasError() {
+		t.Fatalf("Failed to read upgraded teams: %v", diags)
+	}
+	if len(teams["Blue"].Permissions.Elements()) != 1 || !teams["Blue"].Users.IsNull() {
+		t.Errorf("Unexpected upgraded team: %+v", teams["Blue"])
+	}
+
+	if state.OnCreateFailure.ValueString() != onCreateFailureRollback || !state.Description.IsNull() || state.Timeouts.IsNull() {
+		t.Errorf("Unexpected upgraded view: %+v", state)
+	}
+}
+
+// newViewPlan returns a plan for a view with one application and one team,
+// whose IDs are not yet known.
+func newViewPlan(t *testing.T, ctx context.Context, attributes map[string]attr.Value) tfsdk.Plan {
+	t.Helper()
+
+	state := emptyState(ctx, &viewResource{})
+	plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+
+	apps := types.MapValueMust(types.ObjectType{AttrTypes: applicationAttrTypes()}, map[string]attr.Value{
+		"Exercise Map": types.ObjectValueMust(applicationAttrTypes(), map[string]attr.Value{
+			"app_id":             types.StringUnknown(),
+			"url":                types.StringValue("https://map.example.com"),
+			"icon":               types.StringNull(),
+			"embeddable":         types.BoolValue(true),
+			"load_in_background": types.BoolValue(false),
+			"v_id":               types.StringUnknown(),
+			"app_template_id":    types.StringNull(),
+		}),
+	})
+	teams := types.MapValueMust(types.ObjectType{AttrTypes: teamAttrTypes()}, map[string]attr.Value{
+		"Blue Team": types.ObjectValueMust(teamAttrTypes(), map[string]attr.Value{
+			"team_id":      types.StringUnknown(),
+			"role":         types.StringNull(),
+			"permissions":  types.SetNull(types.StringType),
+			"user":         types.ListNull(types.ObjectType{AttrTypes: userInfoAttrTypes()}),
+			"app_instance": types.ListNull(types.ObjectType{AttrTypes: appInstanceAttrTypes()}),
+		}),
+	})
+
+	values := map[string]attr.Value{
+		"id":                types.StringUnknown(),
+		"name":              types.StringValue("Exercise"),
+		"status":            types.StringValue("Active"),
+		"create_admin_team": types.BoolValue(false),
+		"applications":      apps,
+		"teams":             teams,
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	for name, value := range values {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("Failed to set %s in plan: %v", name, diags)
+		}
+	}
+	return plan
+}
+
+// TestViewCreate_OnCreateFailure verifies that a view whose applications fail
+// to be created is deleted with "rollback" and saved to state without its
+// applications, teams and deletion guards with "taint"
+func TestViewCreate_OnCreateFailure(t *testing.T) {
+	tests := []struct {
+		onCreateFailure string
+		expectDelete    bool
+		expectState     bool
+	}{
+		{onCreateFailureRollback, true, false},
+		{onCreateFailureTaint, false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.onCreateFailure, func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests []string
+			r := &viewResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+
+				switch req.Method + " " + req.URL.Path {
+				case "POST /api/views":
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprint(w, `{"id": "view-1"}`)
+				case "DELETE /api/views/view-1":
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			})}
+
+			plan := newViewPlan(t, ctx, map[string]attr.Value{
+				"on_create_failure":   types.StringValue(test.onCreateFailure),
+				"deletion_protection": types.BoolValue(true),
+				"protect_active":      types.BoolValue(true),
+			})
+			resp := &fwresource.CreateResponse{State: emptyState(ctx, r)}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan}, resp)
+
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Error Creating Applications" {
+				t.Fatalf("Expected the application error to be reported, got: %v", resp.Diagnostics)
+			}
+
+			deleted := false
+			for _, request := range requests {
+				if request == "DELETE /api/views/view-1" {
+					deleted = true
+				}
+			}
+			if deleted != test.expectDelete {
+				t.Errorf("Expected rollback DELETE sent to be %v, got requests %v", test.expectDelete, requests)
+			}
+
+			if !test.expectState {
+				if !resp.State.Raw.IsNull() {
+					t.Errorf("Expected no state after rollback, got %s", resp.State.Raw)
+				}
+				return
+			}
+
+			var state viewResourceModel
+			if diags := resp.State.Get(ctx, &state); diags.HasError() {
+				t.Fatalf("Failed to read state: %v", diags)
+			}
+			if state.ID.ValueString() != "view-1" {
+				t.Errorf("Expected the view ID in state, got %s", state.ID)
+			}
+			if !state.Applications.IsNull() || !state.Teams.IsNull() {
+				t.Errorf("Expected null applications and teams, got %s and %s", state.Applications, state.Teams)
+			}
+			if state.DeletionProtection.ValueBool() || state.ProtectActive.ValueBool() {
+				t.Errorf("Expected the deletion guards to be cleared so the view can be replaced, got %+v", state)
+			}
+		})
+	}
+}
+
+// TestViewDelete_Guards verifies that deletion_protection and protect_active refuse to delete a view
+func TestViewDelete_Guards(t *testing.T) {
+	tests := []struct {
+		name               string
+		deletionProtection bool
+		protectActive      bool
+		status             string
+		expectError        string
+		expectDelete       bool
+	}{
+		{"unprotected", false, false, "Active", "", true},
+		{"deletion_protection", true, false, "Inactive", "Deletion Protection Enabled", false},
+		{"protect_active active", false, true, "Active", "View Is Active", false},
+		{"protect_active inactive", false, true, "Inactive", "", true},
+		{"protect_active deleted", false, true, "", "", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests []string
+			r := &viewResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+
+				switch {
+				case test.status == "":
+					w.WriteHeader(http.StatusNotFound)
+				case req.Method == http.MethodGet:
+					w.Header().Set("Content-Type", "application/json")
+					fmt.Fprintf(w, `{"id": "view-1", "name": "Exercise", "status": %q}`, test.status)
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})}
+
+			state := emptyState(ctx, r)
+			state.SetAttribute(ctx, path.Root("id"), "view-1")
+			state.SetAttribute(ctx, path.Root("deletion_protection"), test.deletionProtection)
+			state.SetAttribute(ctx, path.Root("protect_active"), test.protectActive)
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+			if test.expectError == "" && resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+			if test.expectError != "" && (!resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != test.expectError) {
+				t.Fatalf("Expected error %q, got: %v", test.expectError, resp.Diagnostics)
+			}
+
+			deleted := len(requests) > 0 && requests[len(requests)-1] == "DELETE /api/views/view-1"
+			if deleted != test.expectDelete {
+				t.Errorf("Expected DELETE sent to be %v, got requests %v", test.expectDelete, requests)
+			}
+		})
+	}
+}
+
+// TestViewSchema_ApplicationsAndTeamsRequireReplace verifies that adding an
+// application or team replaces the view, since Update cannot create them
+func TestViewSchema_ApplicationsAndTeamsRequireReplace(t *testing.T) {
+	ctx := context.Background()
+
+	var schemaResp fwresource.SchemaResponse
+	(&viewResource{}).Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	app := func(appID types.String) attr.Value {
+		return types.ObjectValueMust(applicationAttrTypes(), map[string]attr.Value{
+			"app_id":             appID,
+			"url":                types.StringValue("https://map.example.com"),
+			"icon":               types.StringNull(),
+			"embeddable":         types.BoolNull(),
+			"load_in_background": types.BoolNull(),
+			"v_id":               appID,
+			"app_template_id":    types.StringNull(),
+		})
+	}
+	team := func(teamID types.String) attr.Value {
+		return types.ObjectValueMust(teamAttrTypes(), map[string]attr.Value{
+			"team_id":      teamID,
+			"role":         types.StringValue("View Member"),
+			"permissions":  types.SetNull(types.StringType),
+			"user":         types.ListNull(types.ObjectType{AttrTypes: userInfoAttrTypes()}),
+			"app_instance": types.ListNull(types.ObjectType{AttrTypes: appInstanceAttrTypes()}),
+		})
+	}
+
+	tests := []struct {
+		attribute string
+		state     types.Map
+		unchanged types.Map
+		added     types.Map
+	}{
+		{
+			"applications",
+			types.MapValueMust(types.ObjectType{AttrTypes: applicationAttrTypes()}, map[string]attr.Value{"Map": app(types.StringValue("app-1"))}),
+			types.MapValueMust(types.ObjectType{AttrTypes: applicationAttrTypes()}, map[string]attr.Value{"Map": app(types.StringValue("app-1"))}),
+			types.MapValueMust(types.ObjectType{AttrTypes: applicationAttrTypes()}, map[string]attr.Value{"Map": app(types.StringValue("app-1")), "Chat": app(types.StringUnknown())}),
+		},
+		{
+			"teams",
+			types.MapValueMust(types.ObjectType{AttrTypes: teamAttrTypes()}, map[string]attr.Value{"Blue Team": team(types.StringValue("team-1"))}),
+			types.MapValueMust(types.ObjectType{AttrTypes: teamAttrTypes()}, map[string]attr.Value{"Blue Team": team(types.StringValue("team-1"))}),
+			types.MapValueMust(types.ObjectType{AttrTypes: teamAttrTypes()}, map[string]attr.Value{"Blue Team": team(types.StringValue("team-1")), "Red Team": team(types.StringUnknown())}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.attribute, func(t *testing.T) {
+			attribute := schemaResp.Schema.Attributes[test.attribute].(schema.MapNestedAttribute)
+
+			// A non-null state and plan mark the view as being updated
+			state := newViewPlan(t, ctx, nil)
+			requiresReplace := func(planValue types.Map) bool {
+				req := planmodifier.MapRequest{
+					Path:        path.Root(test.attribute),
+					StateValue:  test.state,
+					PlanValue:   planValue,
+					ConfigValue: planValue,
+					State:       tfsdk.State{Schema: state.Schema, Raw: state.Raw},
+					Plan:        state,
+				}
+				resp := &planmodifier.MapResponse{PlanValue: planValue}
+				for _, modifier := range attribute.PlanModifiers {
+					modifier.PlanModifyMap(ctx, req, resp)
+				}
+				return resp.RequiresReplace
+			}
+
+			if requiresReplace(test.unchanged) {
+				t.Errorf("Expected unchanged %s not to replace the view", test.attribute)
+			}
+			if !requiresReplace(test.added) {
+				t.Errorf("Expected an added entry in %s to replace the view", test.attribute)
+			}
+		})
+	}
+}
@@ -0,0 +1,485 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithUpgradeState = &viewResource{}
+	_ resource.ResourceWithMoveState    = &viewResource{}
+)
+
+// viewResourceModelV0 describes version 0 of the view schema, in which
+// applications and teams were lists and team permissions were a list.
+type viewResourceModelV0 struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Status             types.String   `tfsdk:"status"`
+	CreateAdminTeam    types.Bool     `tfsdk:"create_admin_team"`
+	Applications       types.List     `tfsdk:"application"`
+	Teams              types.List     `tfsdk:"team"`
+	OnCreateFailure    types.String   `tfsdk:"on_create_failure"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ProtectActive      types.Bool     `tfsdk:"protect_active"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// applicationModelV0 describes an application in version 0 of the view schema.
+type applicationModelV0 struct {
+	AppID            types.String `tfsdk:"app_id"`
+	Name             types.String `tfsdk:"name"`
+	URL              types.String `tfsdk:"url"`
+	Icon             types.String `tfsdk:"icon"`
+	Embeddable       types.Bool   `tfsdk:"embeddable"`
+	LoadInBackground types.Bool   `tfsdk:"load_in_background"`
+	ViewID           types.String `tfsdk:"v_id"`
+	AppTemplateID    types.String `tfsdk:"app_template_id"`
+}
+
+// viewResourceModelV1 describes version 1 of the view schema, in which team
+// permissions were a list.
+type viewResourceModelV1 struct {
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Description        types.String   `tfsdk:"description"`
+	Status             types.String   `tfsdk:"status"`
+	CreateAdminTeam    types.Bool     `tfsdk:"create_admin_team"`
+	Applications       types.Map      `tfsdk:"applications"`
+	Teams              types.Map      `tfsdk:"teams"`
+	OnCreateFailure    types.String   `tfsdk:"on_create_failure"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ProtectActive      types.Bool     `tfsdk:"protect_active"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
+}
+
+// teamModelV1 describes a team in version 1 of the view schema.
+type teamModelV1 struct {
+	TeamID       types.String `tfsdk:"team_id"`
+	Role         types.String `tfsdk:"role"`
+	Permissions  types.List   `tfsdk:"permissions"`
+	Users        types.List   `tfsdk:"user"`
+	AppInstances types.List   `tfsdk:"app_instance"`
+}
+
+// teamModelV0 describes a team in version 0 of the view schema.
+type teamModelV0 struct {
+	TeamID       types.String `tfsdk:"team_id"`
+	Name         types.String `tfsdk:"name"`
+	Role         types.String `tfsdk:"role"`
+	Permissions  types.List   `tfsdk:"permissions"`
+	Users        types.List   `tfsdk:"user"`
+	AppInstances types.List   `tfsdk:"app_instance"`
+}
+
+// UpgradeState upgrades state written by earlier versions of the view schema.
+func (r *viewResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeViewStateV0},
+		1: {
+			PriorSchema:   viewSchemaV1(ctx),
+			StateUpgrader: upgradeViewStateV1,
+		},
+	}
+}
+
+// MoveState accepts view state written under another
+// Crucible provider address or a former type name.
+func (r *viewResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromCrucible("crucible_player_view", formerTypeNames["crucible_player_view"], r.UpgradeState(ctx)),
+	}
+}
+
+// upgradeViewStateV0 converts the application and team lists of version 0
+// into maps keyed by name, and team permissions into sets. Version 0 state is
+// decoded without a schema because the SDK releases stored the application
+// booleans as strings.
+func upgradeViewStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	prior := viewModelV0FromPriorState(ctx, newPriorState(req.RawState, &resp.Diagnostics), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	applications, diags := upgradeApplicationsV0(ctx, prior.Applications)
+	resp.Diagnostics.Append(diags...)
+	teams, diags := upgradeTeamsV0(ctx, prior.Teams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	onCreateFailure := prior.OnCreateFailure
+	if onCreateFailure.IsNull() {
+		onCreateFailure = types.StringValue(onCreateFailureTaint)
+	}
+
+	upgraded := viewResourceModel{
+		ID:                 prior.ID,
+		Name:               prior.Name,
+		Description:        prior.Description,
+		Status:             prior.Status,
+		CreateAdminTeam:    prior.CreateAdminTeam,
+		Applications:       applications,
+		Teams:              teams,
+		OnCreateFailure:    onCreateFailure,
+		DeletionProtection: boolOrDefault(prior.DeletionProtection, false),
+		ProtectActive:      boolOrDefault(prior.ProtectActive, false),
+		Timeouts:           prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// viewModelV0FromPriorState decodes version 0 view state into the types of
+// viewSchemaV0. Empty application, team, user and app_instance lists, which
+// the SDK stored for omitted blocks, become null.
+func viewModelV0FromPriorState(ctx context.Context, prior priorState, diags *diag.Diagnostics) viewResourceModelV0 {
+	var apps []applicationModelV0
+	for _, app := range prior.Objects("application") {
+		apps = append(apps, applicationModelV0{
+			AppID:            app.String("app_id"),
+			Name:             app.String("name"),
+			URL:              app.String("url"),
+			Icon:             app.String("icon"),
+			Embeddable:       app.Bool("embeddable"),
+			LoadInBackground: app.Bool("load_in_background"),
+			ViewID:           app.String("v_id"),
+			AppTemplateID:    app.String("app_template_id"),
+		})
+	}
+
+	var teams []teamModelV0
+	for _, team := range prior.Objects("team") {
+		var users []userInfoModel
+		for _, user := range team.Objects("user") {
+			users = append(users, userInfoModel{
+				UserID: user.String("user_id"),
+				Role:   user.String("role"),
+			})
+		}
+
+		var instances []appInstanceModel
+		for _, instance := range team.Objects("app_instance") {
+			instances = append(instances, appInstanceModel{
+				Name:         instance.String("name"),
+				ID:           instance.String("id"),
+				DisplayOrder: instance.Float64("display_order"),
+			})
+		}
+
+		teams = append(teams, teamModelV0{
+			TeamID:       team.String("team_id"),
+			Name:         team.String("name"),
+			Role:         team.String("role"),
+			Permissions:  listValueOrNull(ctx, types.StringType, team.Strings("permissions"), diags),
+			Users:        listValueOrNull(ctx, types.ObjectType{AttrTypes: userInfoAttrTypes()}, users, diags),
+			AppInstances: listValueOrNull(ctx, types.ObjectType{AttrTypes: appInstanceAttrTypes()}, instances, diags),
+		})
+	}
+
+	schemaV0 := viewSchemaV0(ctx)
+	return viewResourceModelV0{
+		ID:                 prior.String("id"),
+		Name:               prior.String("name"),
+		Description:        prior.String("description"),
+		Status:             prior.String("status"),
+		CreateAdminTeam:    prior.Bool("create_admin_team"),
+		Applications:       listValueOrNull(ctx, schemaV0.Attributes["application"].GetType().(types.ListType).ElemType, apps, diags),
+		Teams:              listValueOrNull(ctx, schemaV0.Attributes["team"].GetType().(types.ListType).ElemType, teams, diags),
+		OnCreateFailure:    prior.String("on_create_failure"),
+		DeletionProtection: prior.Bool("deletion_protection"),
+		ProtectActive:      prior.Bool("protect_active"),
+		Timeouts:           prior.Timeouts("create", "read", "update", "delete"),
+	}
+}
+
+// listValueOrNull returns elements as a list, or a null list if there are none.
+func listValueOrNull[T any](ctx context.Context, elemType attr.Type, elements []T, diags *diag.Diagnostics) types.List {
+	if len(elements) == 0 {
+		return types.ListNull(elemType)
+	}
+
+	list, d := types.ListValueFrom(ctx, elemType, elements)
+	diags.Append(d...)
+	return list
+}
+
+// upgradeViewStateV1 converts the team permissions of version 1 into sets.
+func upgradeViewStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior viewResourceModelV1
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teams, diags := upgradeTeamsV1(ctx, prior.Teams)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	upgraded := viewResourceModel{
+		ID:                 prior.ID,
+		Name:               prior.Name,
+		Description:        prior.Description,
+		Status:             prior.Status,
+		CreateAdminTeam:    prior.CreateAdminTeam,
+		Applications:       prior.Applications,
+		Teams:              teams,
+		OnCreateFailure:    prior.OnCreateFailure,
+		DeletionProtection: prior.DeletionProtection,
+		ProtectActive:      prior.ProtectActive,
+		Timeouts:           prior.Timeouts,
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
+
+// upgradeApplicationsV0 converts a version 0 application list into a map keyed by name.
+func upgradeApplicationsV0(ctx context.Context, list types.List) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: applicationAttrTypes()}
+
+	if list.IsNull() || list.IsUnknown() {
+		return types.MapNull(elemType), diags
+	}
+
+	var prior []applicationModelV0
+	diags.Append(list.ElementsAs(ctx, &prior, false)...)
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	apps := make(map[string]applicationModel, len(prior))
+	for _, app := range prior {
+		name := app.Name.ValueString()
+		if _, ok := apps[name]; ok {
+			diags.Append(duplicateNameDiagnostic("application", name))
+			continue
+		}
+
+		apps[name] = applicationModel{
+			AppID:            app.AppID,
+			URL:              app.URL,
+			Icon:             app.Icon,
+			Embeddable:       app.Embeddable,
+			LoadInBackground: app.LoadInBackground,
+			ViewID:           app.ViewID,
+			AppTemplateID:    app.AppTemplateID,
+		}
+	}
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	m, d := types.MapValueFrom(ctx, elemType, apps)
+	diags.Append(d...)
+	return m, diags
+}
+
+// upgradeTeamsV0 converts a version 0 team list into a map keyed by name.
+func upgradeTeamsV0(ctx context.Context, list types.List) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: teamAttrTypes()}
+
+	if list.IsNull() || list.IsUnknown() {
+		return types.MapNull(elemType), diags
+	}
+
+	var prior []teamModelV0
+	diags.Append(list.ElementsAs(ctx, &prior, false)...)
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	teams := make(map[string]teamModel, len(prior))
+	for _, team := range prior {
+		name := team.Name.ValueString()
+		if _, ok := teams[name]; ok {
+			diags.Append(duplicateNameDiagnostic("team", name))
+			continue
+		}
+
+		permissions, d := listToSet(ctx, team.Permissions)
+		diags.Append(d...)
+
+		teams[name] = teamModel{
+			TeamID:       team.TeamID,
+			Role:         team.Role,
+			Permissions:  permissions,
+			Users:        team.Users,
+			AppInstances: team.AppInstances,
+		}
+	}
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	m, d := types.MapValueFrom(ctx, elemType, teams)
+	diags.Append(d...)
+	return m, diags
+}
+
+// upgradeTeamsV1 converts the permissions of each version 1 team into a set.
+func upgradeTeamsV1(ctx context.Context, prior types.Map) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	elemType := types.ObjectType{AttrTypes: teamAttrTypes()}
+
+	if prior.IsNull() || prior.IsUnknown() {
+		return types.MapNull(elemType), diags
+	}
+
+	var priorTeams map[string]teamModelV1
+	diags.Append(prior.ElementsAs(ctx, &priorTeams, false)...)
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	teams := make(map[string]teamModel, len(priorTeams))
+	for name, team := range priorTeams {
+		permissions, d := listToSet(ctx, team.Permissions)
+		diags.Append(d...)
+
+		teams[name] = teamModel{
+			TeamID:       team.TeamID,
+			Role:         team.Role,
+			Permissions:  permissions,
+			Users:        team.Users,
+			AppInstances: team.AppInstances,
+		}
+	}
+	if diags.HasError() {
+		return types.MapNull(elemType), diags
+	}
+
+	m, d := types.MapValueFrom(ctx, elemType, teams)
+	diags.Append(d...)
+	return m, diags
+}
+
+// duplicateNameDiagnostic reports a version 0 list that cannot be keyed by name.
+func duplicateNameDiagnostic(objectName, name string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Duplicate Name in View State",
+		fmt.Sprintf("The view state contains more than one %[1]s named %[2]q, so it cannot be converted to the %[1]ss map, which is keyed by name. "+
+			"Remove the view from state with terraform state rm, give its %[1]ss unique names, and import it again.", objectName, name),
+	)
+}
+
+// viewSchemaV0 returns version 0 of the view schema, which defines the types
+// of viewResourceModelV0. Descriptions and plan modifiers are omitted.
+func viewSchemaV0(ctx context.Context) *schema.Schema {
+	return priorViewSchema(ctx,
+		"application", schema.ListNestedAttribute{
+			Optional:     true,
+			NestedObject: schema.NestedAttributeObject{Attributes: priorApplicationAttributes(true)},
+		},
+		"team", schema.ListNestedAttribute{
+			Optional:     true,
+			NestedObject: schema.NestedAttributeObject{Attributes: priorTeamAttributes(true)},
+		},
+	)
+}
+
+// viewSchemaV1 returns version 1 of the view schema.
+func viewSchemaV1(ctx context.Context) *schema.Schema {
+	return priorViewSchema(ctx,
+		"applications", schema.MapNestedAttribute{
+			Optional:     true,
+			NestedObject: schema.NestedAttributeObject{Attributes: priorApplicationAttributes(false)},
+		},
+		"teams", schema.MapNestedAttribute{
+			Optional:     true,
+			NestedObject: schema.NestedAttributeObject{Attributes: priorTeamAttributes(false)},
+		},
+	)
+}
+
+// priorViewSchema returns a prior view schema with the given application and team attributes.
+func priorViewSchema(ctx context.Context, appsName string, apps schema.Attribute, teamsName string, teams schema.Attribute) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id":                  schema.StringAttribute{Computed: true},
+			"name":                schema.StringAttribute{Required: true},
+			"description":         schema.StringAttribute{Optional: true},
+			"status":              schema.StringAttribute{Optional: true, Computed: true},
+			"create_admin_team":   schema.BoolAttribute{Optional: true, Computed: true},
+			"on_create_failure":   schema.StringAttribute{Optional: true, Computed: true},
+			"deletion_protection": schema.BoolAttribute{Optional: true, Computed: true},
+			"protect_active":      schema.BoolAttribute{Optional: true, Computed: true},
+			appsName:              apps,
+			teamsName:             teams,
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// priorApplicationAttributes returns the attributes of an application in a
+// prior view schema. Applications in lists also held their name.
+func priorApplicationAttributes(withName bool) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"app_id":             schema.StringAttribute{Computed: true},
+		"url":                schema.StringAttribute{Optional: true},
+		"icon":               schema.StringAttribute{Optional: true},
+		"embeddable":         schema.BoolAttribute{Optional: true},
+		"load_in_background": schema.BoolAttribute{Optional: true},
+		"app_template_id":    schema.StringAttribute{Optional: true},
+		"v_id":               schema.StringAttribute{Computed: true},
+	}
+	if withName {
+		attributes["name"] = schema.StringAttribute{Required: true}
+	}
+	return attributes
+}
+
+// priorTeamAttributes returns the attributes of a team in a prior view schema,
+// where permissions were a list. Teams in lists also held their name.
+func priorTeamAttributes(withName bool) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"team_id":     schema.StringAttribute{Computed: true},
+		"role":        schema.StringAttribute{Optional: true, Computed: true},
+		"permissions": schema.ListAttribute{ElementType: types.StringType, Optional: true},
+		"user": schema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"user_id": schema.StringAttribute{Required: true},
+					"role":    schema.StringAttribute{Optional: true},
+				},
+			},
+		},
+		"app_instance": schema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name":          schema.StringAttribute{Required: true},
+					"display_order": schema.Float64Attribute{Optional: true},
+					"id":            schema.StringAttribute{Computed: true},
+				},
+			},
+		},
+	}
+	if withName {
+		attributes["name"] = schema.StringAttribute{Required: true}
+	}
+	return attributes
+}
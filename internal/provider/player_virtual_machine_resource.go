@@ -5,18 +5,24 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"regexp"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
 	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
 	"github.com/google/uuid"
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -25,14 +31,19 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &vmResource{}
-	_ resource.ResourceWithConfigure   = &vmResource{}
-	_ resource.ResourceWithImportState = &vmResource{}
+	_ resource.Resource                     = &vmResource{}
+	_ resource.ResourceWithConfigure        = &vmResource{}
+	_ resource.ResourceWithImportState      = &vmResource{}
+	_ resource.ResourceWithConfigValidators = &vmResource{}
+	_ resource.ResourceWithIdentity         = &vmResource{}
 )
 
 // NewVMResource is a helper function to simplify the provider implementation.
@@ -47,25 +58,30 @@ type vmResource struct {
 
 // vmResourceModel describes the resource data model.
 type vmResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	VMID              types.String `tfsdk:"vm_id"`
-	URL               types.String `tfsdk:"url"`
-	DefaultURL        types.Bool   `tfsdk:"default_url"`
-	Name              types.String `tfsdk:"name"`
-	TeamIDs           types.List   `tfsdk:"team_ids"`
-	UserID            types.String `tfsdk:"user_id"`
-	Embeddable        types.Bool   `tfsdk:"embeddable"`
-	ConsoleConnection types.Object `tfsdk:"console_connection_info"`
-	ProxmoxInfo       types.Object `tfsdk:"proxmox_vm_info"`
+	ID                 types.String   `tfsdk:"id"`
+	VMID               types.String   `tfsdk:"vm_id"`
+	URL                types.String   `tfsdk:"url"`
+	DefaultURL         types.Bool     `tfsdk:"default_url"`
+	Name               types.String   `tfsdk:"name"`
+	TeamIDs            types.Set      `tfsdk:"team_ids"`
+	UserID             types.String   `tfsdk:"user_id"`
+	Embeddable         types.Bool     `tfsdk:"embeddable"`
+	ConsoleConnection  types.Object   `tfsdk:"console_connection_info"`
+	ProxmoxInfo        types.Object   `tfsdk:"proxmox_vm_info"`
+	OnConflict         types.String   `tfsdk:"on_conflict"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
 // consoleConnectionModel describes console connection nested attribute.
 type consoleConnectionModel struct {
-	Hostname types.String `tfsdk:"hostname"`
-	Port     types.String `tfsdk:"port"`
-	Protocol types.String `tfsdk:"protocol"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Hostname          types.String `tfsdk:"hostname"`
+	Port              types.String `tfsdk:"port"`
+	Protocol          types.String `tfsdk:"protocol"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
 }
 
 // proxmoxInfoModel describes proxmox VM info nested attribute.
@@ -81,9 +97,10 @@ func (r *vmResource) Metadata(_ context.Context, req resource.MetadataRequest, r
 }
 
 // Schema defines the schema for the resource.
-func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *vmResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Player virtual machine resource in Crucible. VMs can be assigned to teams and configured with console connection details for VSphere, Guacamole, or Proxmox.",
+		Version:     2,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:    true,
@@ -97,10 +114,7 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				Computed:    true,
 				Description: "UUID for this virtual machine. If not provided, a UUID will be generated automatically.",
 				Validators: []validator.String{
-					stringvalidator.RegexMatches(
-						regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
-						"must be a valid UUID (lowercase with hyphens)",
-					),
+					validators.UUID(),
 				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -110,6 +124,9 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 			"url": schema.StringAttribute{
 				Optional:    true,
 				Description: "URL for accessing this VM. If not specified, a default URL will be computed by the API based on the VM type.",
+				Validators: []validator.String{
+					validators.AbsoluteURL(),
+				},
 			},
 			"default_url": schema.BoolAttribute{
 				Computed:    true,
@@ -119,17 +136,21 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 				Required:    true,
 				Description: "Display name for this virtual machine.",
 			},
-			"team_ids": schema.ListAttribute{
+			"team_ids": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
-				Description: "List of team UUIDs that can access this VM. Must contain at least one team.",
-				Validators: []validator.List{
-					listvalidator.SizeAtLeast(1),
+				Description: "Set of team UUIDs that can access this VM. Must contain at least one team.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(validators.UUID()),
 				},
 			},
 			"user_id": schema.StringAttribute{
 				Optional:    true,
 				Description: "Optional user UUID to associate with this VM.",
+				Validators: []validator.String{
+					validators.UUID(),
+				},
 			},
 			"embeddable": schema.BoolAttribute{
 				Optional:    true,
@@ -148,12 +169,15 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 					"port": schema.StringAttribute{
 						Optional:    true,
 						Description: "Port number for the console connection.",
+						Validators: []validator.String{
+							validators.Port(),
+						},
 					},
 					"protocol": schema.StringAttribute{
 						Optional:    true,
 						Description: "Protocol for console connection (ssh, vnc, rdp).",
 						Validators: []validator.String{
-							stringvalidator.OneOf("ssh", "vnc", "rdp"),
+							validators.ConsoleProtocol(),
 						},
 					},
 					"username": schema.StringAttribute{
@@ -161,9 +185,26 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 						Description: "Username for console authentication.",
 					},
 					"password": schema.StringAttribute{
+						Optional:           true,
+						Sensitive:          true,
+						Description:        "Password for console authentication. It is stored in state; use password_wo instead.",
+						DeprecationMessage: "Use password_wo with password_wo_version, which is never stored in state, instead.",
+						Validators: []validator.String{
+							stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
+					},
+					"password_wo": schema.StringAttribute{
 						Optional:    true,
 						Sensitive:   true,
-						Description: "Password for console authentication.",
+						WriteOnly:   true,
+						Description: "Password for console authentication. It is sent to the VM API but never stored in state. Change password_wo_version to update the password of an existing VM. Requires Terraform 1.11 or later.",
+					},
+					"password_wo_version": schema.Int64Attribute{
+						Optional:    true,
+						Description: "Version of password_wo. Changing it updates the console password to the current password_wo.",
+						Validators: []validator.Int64{
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("password_wo")),
+						},
 					},
 				},
 			},
@@ -193,10 +234,25 @@ func (r *vmResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *r
 					},
 				},
 			},
+			"on_conflict":         onConflictAttribute("virtual machine", "vm_id"),
+			"deletion_protection": deletionProtectionAttribute("virtual machine"),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// IdentitySchema defines the identity of a virtual machine, its ID.
+func (r *vmResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("virtual machine")
+}
+
 // Configure adds the provider configured client to the resource.
 func (r *vmResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -217,6 +273,9 @@ func (r *vmResource) Configure(_ context.Context, req resource.ConfigureRequest,
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_virtual_machine.Create", &resp.Diagnostics)
+	defer endSpan()
+
 	var data vmResourceModel
 
 	// Read Terraform plan data into the model
@@ -225,8 +284,18 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate VM ID if not provided
 	vmID := data.VMID.ValueString()
+	vmIDConfigured := vmID != ""
 	if vmID == "" {
 		vmID = uuid.NewString()
 		data.VMID = types.StringValue(vmID)
@@ -250,57 +319,33 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	// Handle optional user_id
 	if !data.UserID.IsNull() && !data.UserID.IsUnknown() && data.UserID.ValueString() != "" {
-		vmInfo.UserID = data.UserID.ValueString()
+		vmInfo.UserID = data.UserID.ValueStringPointer()
 	} else {
 		vmInfo.UserID = nil
 	}
 
 	// Handle console_connection_info nested block
-	if !data.ConsoleConnection.IsNull() && !data.ConsoleConnection.IsUnknown() {
-		var connModel consoleConnectionModel
-		resp.Diagnostics.Append(data.ConsoleConnection.As(ctx, &connModel, types.ObjectAsOptions{})...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		vmInfo.Connection = &structs.ConsoleConnection{
-			Hostname: connModel.Hostname.ValueString(),
-			Port:     connModel.Port.ValueString(),
-			Protocol: connModel.Protocol.ValueString(),
-			Username: connModel.Username.ValueString(),
-			Password: connModel.Password.ValueString(),
-		}
+	vmInfo.Connection = consoleConnectionFromPlan(ctx, data.ConsoleConnection, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Handle proxmox_vm_info nested block
-	if !data.ProxmoxInfo.IsNull() && !data.ProxmoxInfo.IsUnknown() {
-		var proxmoxModel proxmoxInfoModel
-		resp.Diagnostics.Append(data.ProxmoxInfo.As(ctx, &proxmoxModel, types.ObjectAsOptions{})...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
+	vmInfo.Proxmox = proxmoxInfoFromObject(ctx, data.ProxmoxInfo, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-		// Parse proxmox ID - can be "100" or "node/qemu/100"
-		proxmoxID := proxmoxModel.ID.ValueString()
-		var intID int
-		if strings.Contains(proxmoxID, "/") {
-			parts := strings.Split(proxmoxID, "/")
-			if len(parts) == 3 {
-				fmt.Sscanf(parts[2], "%d", &intID)
-			}
-		} else {
-			fmt.Sscanf(proxmoxID, "%d", &intID)
-		}
+	// Create VM via API
+	err := api.CreateVM(ctx, r.client, vmInfo)
 
-		vmInfo.Proxmox = &structs.ProxmoxInfo{
-			Id:   intID,
-			Node: proxmoxModel.Node.ValueString(),
-			Type: proxmoxModel.Type.ValueString(),
-		}
+	// Adopt an existing VM with the configured vm_id by updating it to match the plan.
+	// A generated vm_id cannot conflict, so only an explicit one is adopted.
+	if errors.Is(err, client.ErrConflict) && data.OnConflict.ValueString() == onConflictAdopt && vmIDConfigured {
+		err = r.adopt(ctx, vmInfo)
 	}
 
-	// Create VM via API
-	if err := api.CreateVM(ctx, r.client, vmInfo); err != nil {
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Virtual Machine",
 			fmt.Sprintf("Could not create VM '%s': %s", data.Name.ValueString(), err.Error()),
@@ -314,10 +359,83 @@ func (r *vmResource) Create(ctx context.Context, req resource.CreateRequest, res
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	setIDIdentity(ctx, resp.Identity, data.ID, &resp.Diagnostics)
+}
+
+// adopt updates the existing VM with vmInfo.ID to match vmInfo, including its
+// team assignments. The existing VM is read first so that its team assignments
+// and a console password the configuration leaves unset are taken into account.
+func (r *vmResource) adopt(ctx context.Context, vmInfo *structs.VMInfo) error {
+	existing, err := api.GetVMInfo(ctx, r.client, vmInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	tflog.Info(ctx, "Adopting existing virtual machine", map[string]interface{}{"id": vmInfo.ID})
+
+	// Keep the existing console password unless the configuration sets one
+	if vmInfo.Connection != nil && vmInfo.Connection.Password == "" && existing.Connection != nil {
+		vmInfo.Connection.Password = existing.Connection.Password
+	}
+
+	if err := r.syncTeams(ctx, vmInfo.ID, existing.TeamIDs, vmInfo.TeamIDs); err != nil {
+		return err
+	}
+
+	if err := api.UpdateVM(ctx, r.client, vmInfo); err != nil {
+		return err
+	}
+
+	vmInfo.DefaultURL = existing.DefaultURL
+	return nil
+}
+
+// syncTeams removes the VM from teams in oldTeams but not newTeams, then adds it to teams
+// in newTeams but not oldTeams.
+func (r *vmResource) syncTeams(ctx context.Context, vmID string, oldTeams, newTeams []string) error {
+	// Find teams to remove (in old but not in new)
+	var toRemove []string
+	for _, teamID := range oldTeams {
+		if !slices.Contains(newTeams, teamID) {
+			toRemove = append(toRemove, teamID)
+		}
+	}
+
+	// Find teams to add (in new but not in old)
+	var toAdd []string
+	for _, teamID := range newTeams {
+		if !slices.Contains(oldTeams, teamID) {
+			toAdd = append(toAdd, teamID)
+		}
+	}
+
+	// Remove from teams
+	if len(toRemove) > 0 {
+		if err := api.RemoveVMFromTeams(ctx, r.client, vmID, toRemove); err != nil {
+			return err
+		}
+	}
+
+	// Add to teams
+	if len(toAdd) > 0 {
+		if err := api.AddVMToTeams(ctx, r.client, vmID, toAdd); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_virtual_machine.Read", &resp.Diagnostics)
+	defer endSpan()
+
+	// Keep the prior state until the provider configuration is known
+	if !r.client.ConfigKnown() {
+		return
+	}
+
 	var state vmResourceModel
 
 	// Read current state
@@ -326,25 +444,24 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
-	// Check if VM exists
-	exists, err := api.VMExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking VM Existence",
-			fmt.Sprintf("Could not verify if VM %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If VM doesn't exist, remove from state
-	if !exists {
-		resp.State.RemoveResource(ctx)
-		return
-	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
 
 	// Read VM from API
 	vmInfo, err := api.GetVMInfo(ctx, r.client, state.ID.ValueString())
 	if err != nil {
+		// Remove from state if the VM was deleted outside of Terraform
+		if errors.Is(err, client.ErrNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Virtual Machine",
 			fmt.Sprintf("Could not read VM %s: %s", state.ID.ValueString(), err.Error()),
@@ -352,9 +469,6 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		return
 	}
 
-	// Sort team IDs to prevent spurious diffs
-	sort.Strings(vmInfo.TeamIDs)
-
 	// Update state with values from API
 	state.VMID = types.StringValue(vmInfo.ID)
 	state.URL = types.StringValue(vmInfo.URL)
@@ -362,37 +476,41 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	state.Name = types.StringValue(vmInfo.Name)
 	state.Embeddable = types.BoolValue(vmInfo.Embeddable)
 
-	// Convert team IDs to list
-	teamIDValues := make([]attr.Value, len(vmInfo.TeamIDs))
-	for i, id := range vmInfo.TeamIDs {
-		teamIDValues[i] = types.StringValue(id)
-	}
-	teamIDList, diags := types.ListValue(types.StringType, teamIDValues)
+	// Convert team IDs to set
+	teamIDSet, diags := types.SetValueFrom(ctx, types.StringType, vmInfo.TeamIDs)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.TeamIDs = teamIDList
+	state.TeamIDs = teamIDSet
 
 	// Handle optional user_id
-	if vmInfo.UserID != nil {
-		if userIDStr, ok := vmInfo.UserID.(string); ok && userIDStr != "" {
-			state.UserID = types.StringValue(userIDStr)
-		} else {
-			state.UserID = types.StringNull()
-		}
+	if vmInfo.UserID != nil && *vmInfo.UserID != "" {
+		state.UserID = types.StringValue(*vmInfo.UserID)
 	} else {
 		state.UserID = types.StringNull()
 	}
 
-	// Handle console_connection_info nested object
+	// Handle console_connection_info nested object. The password is never read
+	// back from the API: the deprecated password and password_wo_version keep
+	// their values from state, and password_wo is never stored.
 	if vmInfo.Connection != nil {
+		var prior consoleConnectionModel
+		if !state.ConsoleConnection.IsNull() && !state.ConsoleConnection.IsUnknown() {
+			resp.Diagnostics.Append(state.ConsoleConnection.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+
 		connAttrs := map[string]attr.Value{
-			"hostname": types.StringValue(vmInfo.Connection.Hostname),
-			"port":     types.StringValue(vmInfo.Connection.Port),
-			"protocol": types.StringValue(vmInfo.Connection.Protocol),
-			"username": types.StringValue(vmInfo.Connection.Username),
-			"password": types.StringValue(vmInfo.Connection.Password),
+			"hostname":            types.StringValue(vmInfo.Connection.Hostname),
+			"port":                types.StringValue(vmInfo.Connection.Port),
+			"protocol":            types.StringValue(vmInfo.Connection.Protocol),
+			"username":            types.StringValue(vmInfo.Connection.Username),
+			"password":            prior.Password,
+			"password_wo":         types.StringNull(),
+			"password_wo_version": prior.PasswordWOVersion,
 		}
 		connObj, diags := types.ObjectValue(consoleConnectionAttrTypes(), connAttrs)
 		resp.Diagnostics.Append(diags...)
@@ -407,7 +525,7 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 	// Handle proxmox_vm_info nested object
 	if vmInfo.Proxmox != nil {
 		proxmoxAttrs := map[string]attr.Value{
-			"id":   types.StringValue(fmt.Sprintf("%d", vmInfo.Proxmox.Id)),
+			"id":   types.StringValue(proxmoxIDForState(ctx, state.ProxmoxInfo, vmInfo.Proxmox.Id)),
 			"node": types.StringValue(vmInfo.Proxmox.Node),
 			"type": types.StringValue(vmInfo.Proxmox.Type),
 		}
@@ -421,12 +539,20 @@ func (r *vmResource) Read(ctx context.Context, req resource.ReadRequest, resp *r
 		state.ProxmoxInfo = types.ObjectNull(proxmoxInfoAttrTypes())
 	}
 
+	// on_conflict is not stored in Player, so default it for imported VMs
+	state.OnConflict = onConflictOrDefault(state.OnConflict)
+	state.DeletionProtection = boolOrDefault(state.DeletionProtection, false)
+
 	// Save updated state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setIDIdentity(ctx, resp.Identity, state.ID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_virtual_machine.Update", &resp.Diagnostics)
+	defer endSpan()
+
 	var plan, state vmResourceModel
 
 	// Read both plan and current state
@@ -436,6 +562,15 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Handle team membership changes
 	if !plan.TeamIDs.Equal(state.TeamIDs) {
 		var oldTeams, newTeams []string
@@ -445,56 +580,12 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 			return
 		}
 
-		// Find teams to remove (in old but not in new)
-		var toRemove []string
-		for _, teamID := range oldTeams {
-			found := false
-			for _, newTeam := range newTeams {
-				if teamID == newTeam {
-					found = true
-					break
-				}
-			}
-			if !found {
-				toRemove = append(toRemove, teamID)
-			}
-		}
-
-		// Find teams to add (in new but not in old)
-		var toAdd []string
-		for _, teamID := range newTeams {
-			found := false
-			for _, oldTeam := range oldTeams {
-				if teamID == oldTeam {
-					found = true
-					break
-				}
-			}
-			if !found {
-				toAdd = append(toAdd, teamID)
-			}
-		}
-
-		// Remove from teams
-		if len(toRemove) > 0 {
-			if err := api.RemoveVMFromTeams(ctx, r.client, state.ID.ValueString(), toRemove); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Removing VM from Teams",
-					fmt.Sprintf("Could not remove VM %s from teams: %s", state.ID.ValueString(), err.Error()),
-				)
-				return
-			}
-		}
-
-		// Add to teams
-		if len(toAdd) > 0 {
-			if err := api.AddVMToTeams(ctx, r.client, state.ID.ValueString(), toAdd); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Adding VM to Teams",
-					fmt.Sprintf("Could not add VM %s to teams: %s", state.ID.ValueString(), err.Error()),
-				)
-				return
-			}
+		if err := r.syncTeams(ctx, state.ID.ValueString(), oldTeams, newTeams); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Updating VM Teams",
+				fmt.Sprintf("Could not update teams for VM %s: %s", state.ID.ValueString(), err.Error()),
+			)
+			return
 		}
 	}
 
@@ -516,54 +607,45 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 
 	// Handle optional user_id
 	if !plan.UserID.IsNull() && !plan.UserID.IsUnknown() && plan.UserID.ValueString() != "" {
-		vmInfo.UserID = plan.UserID.ValueString()
+		vmInfo.UserID = plan.UserID.ValueStringPointer()
 	} else {
 		vmInfo.UserID = nil
 	}
 
 	// Handle console_connection_info
-	if !plan.ConsoleConnection.IsNull() && !plan.ConsoleConnection.IsUnknown() {
-		var connModel consoleConnectionModel
-		resp.Diagnostics.Append(plan.ConsoleConnection.As(ctx, &connModel, types.ObjectAsOptions{})...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		vmInfo.Connection = &structs.ConsoleConnection{
-			Hostname: connModel.Hostname.ValueString(),
-			Port:     connModel.Port.ValueString(),
-			Protocol: connModel.Protocol.ValueString(),
-			Username: connModel.Username.ValueString(),
-			Password: connModel.Password.ValueString(),
-		}
+	vmInfo.Connection = consoleConnectionFromPlan(ctx, plan.ConsoleConnection, req.Config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Handle proxmox_vm_info
-	if !plan.ProxmoxInfo.IsNull() && !plan.ProxmoxInfo.IsUnknown() {
-		var proxmoxModel proxmoxInfoModel
-		resp.Diagnostics.Append(plan.ProxmoxInfo.As(ctx, &proxmoxModel, types.ObjectAsOptions{})...)
+	// The VM API replaces the console connection, clearing the password if it
+	// is left out, so send the VM's current password unless it changed
+	if vmInfo.Connection != nil && !consolePasswordChanged(ctx, plan.ConsoleConnection, state.ConsoleConnection, &resp.Diagnostics) {
 		if resp.Diagnostics.HasError() {
 			return
 		}
 
-		proxmoxID := proxmoxModel.ID.ValueString()
-		var intID int
-		if strings.Contains(proxmoxID, "/") {
-			parts := strings.Split(proxmoxID, "/")
-			if len(parts) == 3 {
-				fmt.Sscanf(parts[2], "%d", &intID)
-			}
-		} else {
-			fmt.Sscanf(proxmoxID, "%d", &intID)
+		existing, err := api.GetVMInfo(ctx, r.client, state.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Virtual Machine",
+				fmt.Sprintf("Could not read the console password of VM %s: %s", state.ID.ValueString(), err.Error()),
+			)
+			return
 		}
 
-		vmInfo.Proxmox = &structs.ProxmoxInfo{
-			Id:   intID,
-			Node: proxmoxModel.Node.ValueString(),
-			Type: proxmoxModel.Type.ValueString(),
+		vmInfo.Connection.Password = ""
+		if existing.Connection != nil {
+			vmInfo.Connection.Password = existing.Connection.Password
 		}
 	}
 
+	// Handle proxmox_vm_info
+	vmInfo.Proxmox = proxmoxInfoFromObject(ctx, plan.ProxmoxInfo, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Update VM via API
 	if err := api.UpdateVM(ctx, r.client, vmInfo); err != nil {
 		resp.Diagnostics.AddError(
@@ -578,10 +660,14 @@ func (r *vmResource) Update(ctx context.Context, req resource.UpdateRequest, res
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setIDIdentity(ctx, resp.Identity, plan.ID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *vmResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, endSpan := startSpan(ctx, "crucible_player_virtual_machine.Delete", &resp.Diagnostics)
+	defer endSpan()
+
 	var state vmResourceModel
 
 	// Read current state
@@ -590,21 +676,20 @@ func (r *vmResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 		return
 	}
 
-	// Check if VM exists
-	exists, err := api.VMExists(ctx, r.client, state.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Checking VM Existence",
-			fmt.Sprintf("Could not verify if VM %s exists: %s", state.ID.ValueString(), err.Error()),
-		)
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If VM doesn't exist, nothing to delete
-	if !exists {
+	if state.DeletionProtection.ValueBool() {
+		addDeletionProtectedError(&resp.Diagnostics, "VM", state.ID.ValueString())
 		return
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Delete VM via API
 	if err := api.DeleteVM(ctx, r.client, state.ID.ValueString()); err != nil {
 		resp.Diagnostics.AddError(
@@ -615,21 +700,257 @@ func (r *vmResource) Delete(ctx context.Context, req resource.DeleteRequest, res
 	}
 }
 
-// ImportState imports an existing resource into Terraform state.
+// ImportState imports an existing VM by ID, or by name with "name:<name>".
 func (r *vmResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the VM ID as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByIDOrName(ctx, r.client, req, resp, "virtual machine", func(ctx context.Context, name string) (string, error) {
+		return api.FindVMByName(ctx, r.client, name)
+	})
+}
+
+// ConfigValidators returns validators for combinations of VM attributes.
+func (r *vmResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(
+			path.MatchRoot("console_connection_info"),
+			path.MatchRoot("proxmox_vm_info"),
+		),
+		// Proxmox VMs are opened at the default URL. A url is allowed with
+		// console_connection_info, as the address of a Guacamole server.
+		resourcevalidator.Conflicting(
+			path.MatchRoot("url"),
+			path.MatchRoot("proxmox_vm_info"),
+		),
+		vmConsoleValidator{},
+	}
+}
+
+// vmConsoleValidator checks the contents of console_connection_info and proxmox_vm_info.
+type vmConsoleValidator struct{}
+
+func (v vmConsoleValidator) Description(_ context.Context) string {
+	return "console_connection_info must set hostname and protocol, and proxmox_vm_info must set a valid id and a node"
+}
+
+func (v vmConsoleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v vmConsoleValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data vmResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ConsoleConnection.IsNull() && !data.ConsoleConnection.IsUnknown() {
+		var connModel consoleConnectionModel
+		resp.Diagnostics.Append(data.ConsoleConnection.As(ctx, &connModel, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		connPath := path.Root("console_connection_info")
+		if connModel.Hostname.IsNull() {
+			resp.Diagnostics.AddAttributeError(connPath.AtName("hostname"), "Missing Console Hostname",
+				"console_connection_info must set hostname, the address Guacamole connects to.")
+		}
+		if connModel.Protocol.IsNull() {
+			resp.Diagnostics.AddAttributeError(connPath.AtName("protocol"), "Missing Console Protocol",
+				"console_connection_info must set protocol to ssh, vnc or rdp.")
+		}
+	}
+
+	if !data.ProxmoxInfo.IsNull() && !data.ProxmoxInfo.IsUnknown() {
+		var proxmoxModel proxmoxInfoModel
+		resp.Diagnostics.Append(data.ProxmoxInfo.As(ctx, &proxmoxModel, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		validateProxmoxModel(proxmoxModel, &resp.Diagnostics)
+	}
+}
+
+// proxmoxID is a parsed proxmox_vm_info.id.
+type proxmoxID struct {
+	// Node and Type are only set when the ID is in node/type/vmid form.
+	Node string
+	Type string
+	VMID int
+}
+
+// parseProxmoxID parses a Proxmox VM ID, given either as a number such as
+// "100" or in the Proxmox provider's "node/qemu/100" form.
+func parseProxmoxID(id string) (proxmoxID, error) {
+	var parsed proxmoxID
+
+	vmid := id
+	if strings.Contains(id, "/") {
+		parts := strings.Split(id, "/")
+		if len(parts) != 3 || parts[0] == "" {
+			return parsed, fmt.Errorf("Proxmox ID %q must be a number such as \"100\" or in the form \"node/qemu/100\"", id)
+		}
+
+		switch strings.ToLower(parts[1]) {
+		case "qemu":
+			parsed.Type = "QEMU"
+		case "lxc":
+			parsed.Type = "LXC"
+		default:
+			return parsed, fmt.Errorf("Proxmox ID %q has type %q, which must be qemu or lxc", id, parts[1])
+		}
+
+		parsed.Node = parts[0]
+		vmid = parts[2]
+	}
+
+	number, err := strconv.Atoi(vmid)
+	if err != nil || number < 100 || number > 999999999 {
+		return parsed, fmt.Errorf("Proxmox ID %q must end in a VM ID between 100 and 999999999", id)
+	}
+	parsed.VMID = number
+
+	return parsed, nil
+}
+
+// validateProxmoxModel reports a missing or malformed Proxmox ID, and a node or
+// type that contradicts the one given in the ID.
+func validateProxmoxModel(model proxmoxInfoModel, diags *diag.Diagnostics) {
+	proxmoxPath := path.Root("proxmox_vm_info")
+
+	if model.ID.IsUnknown() {
+		return
+	}
+	if model.ID.IsNull() {
+		diags.AddAttributeError(proxmoxPath.AtName("id"), "Missing Proxmox ID",
+			"proxmox_vm_info must set id, either as a number such as \"100\" or in the form \"node/qemu/100\".")
+		return
+	}
+
+	parsed, err := parseProxmoxID(model.ID.ValueString())
+	if err != nil {
+		diags.AddAttributeError(proxmoxPath.AtName("id"), "Invalid Proxmox ID", err.Error())
+		return
+	}
+
+	if model.Node.IsNull() && parsed.Node == "" {
+		diags.AddAttributeError(proxmoxPath.AtName("node"), "Missing Proxmox Node",
+			"proxmox_vm_info must set node, or give the node in id using the form \"node/qemu/100\".")
+	}
+	if !model.Node.IsNull() && !model.Node.IsUnknown() && parsed.Node != "" && model.Node.ValueString() != parsed.Node {
+		diags.AddAttributeError(proxmoxPath.AtName("node"), "Conflicting Proxmox Node",
+			fmt.Sprintf("node %q does not match node %q in id %q.", model.Node.ValueString(), parsed.Node, model.ID.ValueString()))
+	}
+	if !model.Type.IsNull() && !model.Type.IsUnknown() && parsed.Type != "" && model.Type.ValueString() != parsed.Type {
+		diags.AddAttributeError(proxmoxPath.AtName("type"), "Conflicting Proxmox Type",
+			fmt.Sprintf("type %q does not match type %q in id %q. Set type = %q.", model.Type.ValueString(), parsed.Type, model.ID.ValueString(), parsed.Type))
+	}
+}
+
+// proxmoxInfoFromObject converts a planned proxmox_vm_info object into the API
+// representation, or returns nil if it is not set.
+func proxmoxInfoFromObject(ctx context.Context, obj types.Object, diags *diag.Diagnostics) *structs.ProxmoxInfo {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil
+	}
+
+	var proxmoxModel proxmoxInfoModel
+	diags.Append(obj.As(ctx, &proxmoxModel, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	validateProxmoxModel(proxmoxModel, diags)
+	if diags.HasError() {
+		return nil
+	}
+
+	parsed, _ := parseProxmoxID(proxmoxModel.ID.ValueString())
+	node := proxmoxModel.Node.ValueString()
+	if node == "" {
+		node = parsed.Node
+	}
+
+	return &structs.ProxmoxInfo{
+		Id:   parsed.VMID,
+		Node: node,
+		Type: proxmoxModel.Type.ValueString(),
+	}
+}
+
+// proxmoxIDForState returns the proxmox_vm_info.id to save for vmid, keeping
+// the form already in state when it refers to the same VM.
+func proxmoxIDForState(ctx context.Context, prior types.Object, vmid int) string {
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var proxmoxModel proxmoxInfoModel
+		if diags := prior.As(ctx, &proxmoxModel, basetypes.ObjectAsOptions{}); !diags.HasError() {
+			if parsed, err := parseProxmoxID(proxmoxModel.ID.ValueString()); err == nil && parsed.VMID == vmid {
+				return proxmoxModel.ID.ValueString()
+			}
+		}
+	}
+
+	return strconv.Itoa(vmid)
+}
+
+// consoleConnectionFromPlan returns the console connection to send to the VM
+// API for a planned console_connection_info, or nil if it is not set. The
+// password is taken from password_wo in config when it is set, since
+// write-only values are never in the plan.
+func consoleConnectionFromPlan(ctx context.Context, plan types.Object, config tfsdk.Config, diags *diag.Diagnostics) *structs.ConsoleConnection {
+	if plan.IsNull() || plan.IsUnknown() {
+		return nil
+	}
+
+	var connModel consoleConnectionModel
+	diags.Append(plan.As(ctx, &connModel, basetypes.ObjectAsOptions{})...)
+
+	var passwordWO types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("console_connection_info").AtName("password_wo"), &passwordWO)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	password := connModel.Password
+	if !passwordWO.IsNull() {
+		password = passwordWO
+	}
+
+	return &structs.ConsoleConnection{
+		Hostname: connModel.Hostname.ValueString(),
+		Port:     connModel.Port.ValueString(),
+		Protocol: connModel.Protocol.ValueString(),
+		Username: connModel.Username.ValueString(),
+		Password: password.ValueString(),
+	}
+}
+
+// consolePasswordChanged reports whether an update must send the console
+// password from config: when console_connection_info is added, or when the
+// deprecated password or password_wo_version changes.
+func consolePasswordChanged(ctx context.Context, plan, state types.Object, diags *diag.Diagnostics) bool {
+	if state.IsNull() || state.IsUnknown() {
+		return true
+	}
+
+	var planned, prior consoleConnectionModel
+	diags.Append(plan.As(ctx, &planned, basetypes.ObjectAsOptions{})...)
+	diags.Append(state.As(ctx, &prior, basetypes.ObjectAsOptions{})...)
+
+	return !planned.Password.Equal(prior.Password) || !planned.PasswordWOVersion.Equal(prior.PasswordWOVersion)
 }
 
 // Helper functions for nested attribute types
 
 func consoleConnectionAttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"hostname": types.StringType,
-		"port":     types.StringType,
-		"protocol": types.StringType,
-		"username": types.StringType,
-		"password": types.StringType,
+		"hostname":            types.StringType,
+		"port":                types.StringType,
+		"protocol":            types.StringType,
+		"username":            types.StringType,
+		"password":            types.StringType,
+		"password_wo":         types.StringType,
+		"password_wo_version": types.Int64Type,
 	}
 }
 
@@ -0,0 +1,85 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/api"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &vmListResource{}
+
+// NewVMListResource is a helper function to simplify the provider implementation.
+func NewVMListResource() list.ListResource {
+	return &vmListResource{}
+}
+
+// vmListResource lists existing virtual machines for terraform query.
+type vmListResource struct {
+	listResourceClient
+}
+
+// vmListConfigModel describes the filters of the VM list resource.
+type vmListConfigModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	TeamID     types.String `tfsdk:"team_id"`
+}
+
+// Metadata returns the resource type name.
+func (r *vmListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_player_virtual_machine"
+}
+
+// ListResourceConfigSchema defines the filters of the list resource.
+func (r *vmListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing virtual machines in the VM API.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": namePrefixAttribute("virtual machine"),
+			"team_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list virtual machines in the team with this ID.",
+				Validators: []validator.String{
+					validators.UUID(),
+				},
+			},
+		},
+	}
+}
+
+// List streams the virtual machines that match the filters.
+func (r *vmListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config vmListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if !requireKnownConfig(r.client, &diags) || diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	streamListResults(ctx, req, stream, "crucible_player_virtual_machine.List", "virtual machine", func(ctx context.Context, yield func(list.ListResult) error) error {
+		return api.ListVMs(ctx, r.client, config.TeamID.ValueString(), func(vm structs.VMInfo) error {
+			if !hasNamePrefix(vm.Name, config.NamePrefix) {
+				return nil
+			}
+
+			return yield(newListResult(ctx, req, idIdentityModel{ID: types.StringValue(vm.ID)}, vm.Name, map[string]any{
+				"id":          vm.ID,
+				"vm_id":       vm.ID,
+				"name":        vm.Name,
+				"url":         vm.URL,
+				"default_url": vm.DefaultURL,
+				"embeddable":  vm.Embeddable,
+				"team_ids":    vm.TeamIDs,
+			}))
+		})
+	})
+}
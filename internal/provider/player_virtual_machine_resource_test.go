@@ -4,9 +4,19 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/cmu-sei/terraform-provider-crucible/internal/structs"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -70,11 +80,21 @@ func TestAccVMResource_WithConsoleConnection(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccVMResourceConfigWithConsole("550e8400-e29b-41d4-a716-446655440012", "VM With Console"),
+				Config: testAccVMResourceConfigWithConsole("550e8400-e29b-41d4-a716-446655440012", "VM With Console", "testpass", 1),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.hostname", "console.example.com"),
 					resource.TestCheckResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.port", "5900"),
 					resource.TestCheckResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.protocol", "vnc"),
+					resource.TestCheckNoResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.password"),
+					resource.TestCheckNoResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.password_wo"),
+				),
+			},
+			// Rotate the write-only password
+			{
+				Config: testAccVMResourceConfigWithConsole("550e8400-e29b-41d4-a716-446655440012", "VM With Console", "rotated", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.password_wo_version", "2"),
+					resource.TestCheckNoResourceAttr("crucible_player_virtual_machine.test", "console_connection_info.password_wo"),
 				),
 			},
 		},
@@ -130,7 +150,7 @@ resource "crucible_player_virtual_machine" "test" {
 	return config
 }
 
-func testAccVMResourceConfigWithConsole(vmID, name string) string {
+func testAccVMResourceConfigWithConsole(vmID, name, password string, passwordVersion int) string {
 	return fmt.Sprintf(`
 provider "crucible" {}
 
@@ -143,10 +163,12 @@ resource "crucible_player_virtual_machine" "test" {
     port     = "5900"
     protocol = "vnc"
     username = "testuser"
-    password = "testpass"
+
+    password_wo         = %[3]q
+    password_wo_version = %[4]d
   }
 }
-`, vmID, name)
+`, vmID, name, password, passwordVersion)
 }
 
 func testAccVMResourceConfigWithProxmox(vmID, name string) string {
@@ -165,3 +187,435 @@ resource "crucible_player_virtual_machine" "test" {
 }
 `, vmID, name)
 }
+
+// TestParseProxmoxID verifies strict parsing of numeric and node/type/vmid Proxmox IDs
+func TestParseProxmoxID(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  proxmoxID
+		wantError bool
+	}{
+		{"100", proxmoxID{VMID: 100}, false},
+		{"pve/qemu/100", proxmoxID{Node: "pve", Type: "QEMU", VMID: 100}, false},
+		{"pve/lxc/2001", proxmoxID{Node: "pve", Type: "LXC", VMID: 2001}, false},
+		{"", proxmoxID{}, true},
+		{"abc", proxmoxID{}, true},
+		{"100abc", proxmoxID{}, true},
+		{"99", proxmoxID{}, true},
+		{"pve/qemu", proxmoxID{}, true},
+		{"pve/vm/100", proxmoxID{}, true},
+		{"/qemu/100", proxmoxID{}, true},
+		{"pve/qemu/x", proxmoxID{}, true},
+	}
+
+	for _, test := range tests {
+		parsed, err := parseProxmoxID(test.input)
+		if (err != nil) != test.wantError {
+			t.Errorf("parseProxmoxID(%q) error = %v, wantError %v", test.input, err, test.wantError)
+			continue
+		}
+		if !test.wantError && parsed != test.expected {
+			t.Errorf("parseProxmoxID(%q) = %+v, want %+v", test.input, parsed, test.expected)
+		}
+	}
+}
+
+// TestUpgradeVMStateV0_SDK verifies that SDK VM state with single-element nested lists upgrades to objects
+func TestUpgradeVMStateV0_SDK(t *testing.T) {
+	var state vmResourceModel
+	upgradeStateFixture(t, &vmResource{}, 0, "player_virtual_machine_v0_sdk.json", &state)
+
+	if state.VMID.ValueString() != "6a7ec409-d275-4b31-94d3-a51cb61d2519" || !state.URL.IsNull() || !state.UserID.IsNull() {
+		t.Errorf("Unexpected upgraded VM: %+v", state)
+	}
+	if !state.ConsoleConnection.IsNull() {
+		t.Errorf("Expected an empty console_connection_info list to upgrade to null, got %s", state.ConsoleConnection)
+	}
+
+	proxmox := state.ProxmoxInfo.Attributes()
+	if !proxmox["id"].Equal(types.StringValue("100")) || !proxmox["node"].Equal(types.StringValue("pve")) || !proxmox["type"].Equal(types.StringValue("QEMU")) {
+		t.Errorf("Unexpected upgraded proxmox_vm_info: %s", state.ProxmoxInfo)
+	}
+	if len(state.TeamIDs.Elements()) != 1 || state.DeletionProtection.ValueBool() || state.OnConflict.ValueString() != onConflictError {
+		t.Errorf("Unexpected upgraded VM: %+v", state)
+	}
+}
+
+// TestUpgradeVMStateV0_Framework verifies that framework VM state upgrades team_ids to a set
+func TestUpgradeVMStateV0_Framework(t *testing.T) {
+	var state vmResourceModel
+	upgradeStateFixture(t, &vmResource{}, 0, "player_virtual_machine_v0.json", &state)
+
+	if len(state.TeamIDs.Elements()) != 2 {
+		t.Errorf("Expected duplicate team IDs to be dropped, got %s", state.TeamIDs)
+	}
+	console := state.ConsoleConnection.Attributes()
+	if !console["hostname"].Equal(types.StringValue("vm1.example.local")) || !console["port"].Equal(types.StringValue("22")) {
+		t.Errorf("Unexpected upgraded console_connection_info: %s", state.ConsoleConnection)
+	}
+	if !console["password"].Equal(types.StringValue("example")) {
+		t.Errorf("Expected the deprecated console password to be kept, got %s", console["password"])
+	}
+	if !state.ProxmoxInfo.IsNull() || state.Embeddable.ValueBool() {
+		t.Errorf("Unexpected upgraded VM: %+v", state)
+	}
+}
+
+// TestUpgradeVMStateV1 verifies that version 1 state is copied to the current schema
+func TestUpgradeVMStateV1(t *testing.T) {
+	var state vmResourceModel
+	upgradeStateFixture(t, &vmResource{}, 1, "player_virtual_machine_v1.json", &state)
+
+	console := state.ConsoleConnection.Attributes()
+	if !console["password"].Equal(types.StringValue("example")) || !console["password_wo"].IsNull() {
+		t.Errorf("Expected only the deprecated console password in upgraded state, got %s", state.ConsoleConnection)
+	}
+	if !console["password_wo_version"].Equal(types.Int64Value(3)) || !console["username"].Equal(types.StringValue("user")) {
+		t.Errorf("Unexpected upgraded console_connection_info: %s", state.ConsoleConnection)
+	}
+	if !state.DeletionProtection.ValueBool() || state.OnConflict.ValueString() != onConflictAdopt || !state.Embeddable.ValueBool() {
+		t.Errorf("Unexpected upgraded VM: %+v", state)
+	}
+}
+
+// TestConsoleConnectionFromPlan verifies that the console password comes from password_wo in config when it is set
+func TestConsoleConnectionFromPlan(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		password   types.String
+		passwordWO types.String
+		expected   string
+	}{
+		{"write-only", types.StringNull(), types.StringValue("secret"), "secret"},
+		{"deprecated", types.StringValue("stored"), types.StringNull(), "stored"},
+		{"none", types.StringNull(), types.StringNull(), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := emptyState(ctx, &vmResource{})
+			config.SetAttribute(ctx, path.Root("console_connection_info"), consoleConnectionModel{
+				Hostname:          types.StringValue("vm1.example.local"),
+				Port:              types.StringNull(),
+				Protocol:          types.StringValue("ssh"),
+				Username:          types.StringNull(),
+				Password:          test.password,
+				PasswordWO:        test.passwordWO,
+				PasswordWOVersion: types.Int64Null(),
+			})
+
+			// Write-only values are null in the plan
+			var plan types.Object
+			config.GetAttribute(ctx, path.Root("console_connection_info"), &plan)
+			plan, _ = types.ObjectValue(consoleConnectionAttrTypes(), withNullPasswordWO(plan))
+
+			var diags diag.Diagnostics
+			conn := consoleConnectionFromPlan(ctx, plan, tfsdk.Config{Schema: config.Schema, Raw: config.Raw}, &diags)
+			if diags.HasError() {
+				t.Fatalf("Unexpected errors: %v", diags)
+			}
+			if conn == nil || conn.Hostname != "vm1.example.local" || conn.Password != test.expected {
+				t.Errorf("Expected password %q, got %+v", test.expected, conn)
+			}
+		})
+	}
+
+	var diags diag.Diagnostics
+	if conn := consoleConnectionFromPlan(ctx, types.ObjectNull(consoleConnectionAttrTypes()), tfsdk.Config{}, &diags); conn != nil {
+		t.Errorf("Expected no console connection for a null plan, got %+v", conn)
+	}
+}
+
+// withNullPasswordWO returns the attributes of a console_connection_info
+// object with password_wo nulled, as it is in a plan.
+func withNullPasswordWO(obj types.Object) map[string]attr.Value {
+	attrs := make(map[string]attr.Value, len(obj.Attributes()))
+	for name, value := range obj.Attributes() {
+		attrs[name] = value
+	}
+	attrs["password_wo"] = types.StringNull()
+	return attrs
+}
+
+// TestVMDelete_DeletionProtection verifies that deletion_protection refuses to delete a VM
+func TestVMDelete_DeletionProtection(t *testing.T) {
+	for _, deletionProtection := range []bool{false, true} {
+		t.Run(fmt.Sprintf("deletion_protection=%v", deletionProtection), func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests []string
+			r := &vmResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				w.WriteHeader(http.StatusNoContent)
+			})}
+
+			state := emptyState(ctx, r)
+			state.SetAttribute(ctx, path.Root("id"), "vm-1")
+			state.SetAttribute(ctx, path.Root("deletion_protection"), deletionProtection)
+
+			resp := &fwresource.DeleteResponse{State: state}
+			r.Delete(ctx, fwresource.DeleteRequest{State: state}, resp)
+
+			if !deletionProtection {
+				if resp.Diagnostics.HasError() || len(requests) != 1 || requests[0] != "DELETE /api/vms/vm-1" {
+					t.Errorf("Expected the VM to be deleted, got requests %v and diagnostics %v", requests, resp.Diagnostics)
+				}
+				return
+			}
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Deletion Protection Enabled" {
+				t.Errorf("Expected deletion protection error, got: %v", resp.Diagnostics)
+			}
+			if len(requests) != 0 {
+				t.Errorf("Expected no API requests, got %v", requests)
+			}
+		})
+	}
+}
+
+// TestVMUpdate_ConsolePassword verifies that updates only send the configured
+// console password when it changed, and otherwise keep the VM's current one
+func TestVMUpdate_ConsolePassword(t *testing.T) {
+	console := func(passwordWOVersion types.Int64) consoleConnectionModel {
+		return consoleConnectionModel{
+			Hostname:          types.StringValue("vm1.example.local"),
+			Port:              types.StringNull(),
+			Protocol:          types.StringValue("ssh"),
+			Username:          types.StringNull(),
+			Password:          types.StringNull(),
+			PasswordWO:        types.StringNull(),
+			PasswordWOVersion: passwordWOVersion,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		stateConsole  bool
+		stateVersion  types.Int64
+		planVersion   types.Int64
+		expected      string
+		expectLookups int
+	}{
+		{"unchanged version", true, types.Int64Value(1), types.Int64Value(1), "current", 1},
+		{"no version", true, types.Int64Null(), types.Int64Null(), "current", 1},
+		{"changed version", true, types.Int64Value(1), types.Int64Value(2), "new", 0},
+		{"added connection", false, types.Int64Null(), types.Int64Null(), "new", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			var lookups int
+			var sent structs.VMInfo
+			r := &vmResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch req.Method {
+				case http.MethodGet:
+					lookups++
+					json.NewEncoder(w).Encode(structs.VMInfo{
+						ID:         "vm-1",
+						Name:       "VM",
+						Connection: &structs.ConsoleConnection{Hostname: "vm1.example.local", Protocol: "ssh", Password: "current"},
+					})
+				case http.MethodPut:
+					json.NewDecoder(req.Body).Decode(&sent)
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte("{}"))
+				default:
+					t.Errorf("Unexpected request %s %s", req.Method, req.URL.Path)
+				}
+			})}
+
+			state := emptyState(ctx, r)
+			state.SetAttribute(ctx, path.Root("id"), "vm-1")
+			state.SetAttribute(ctx, path.Root("name"), "VM")
+			if test.stateConsole {
+				state.SetAttribute(ctx, path.Root("console_connection_info"), console(test.stateVersion))
+			}
+
+			// Only the name and password_wo_version change; password_wo is
+			// only in config
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw.Copy()}
+			plan.SetAttribute(ctx, path.Root("name"), "Renamed VM")
+			plan.SetAttribute(ctx, path.Root("console_connection_info"), console(test.planVersion))
+			configState := tfsdk.State{Schema: state.Schema, Raw: plan.Raw.Copy()}
+			configState.SetAttribute(ctx, path.Root("console_connection_info").AtName("password_wo"), "new")
+			config := tfsdk.Config{Schema: state.Schema, Raw: configState.Raw}
+
+			resp := &fwresource.UpdateResponse{State: state}
+			r.Update(ctx, fwresource.UpdateRequest{Plan: plan, State: state, Config: config}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+			if sent.Connection == nil || sent.Connection.Password != test.expected {
+				t.Errorf("Expected console password %q to be sent, got %+v", test.expected, sent.Connection)
+			}
+			if lookups != test.expectLookups {
+				t.Errorf("Expected %d lookups of the current password, got %d", test.expectLookups, lookups)
+			}
+		})
+	}
+}
+
+// TestVMCreate_OnConflict verifies that an existing VM is adopted only with
+// on_conflict = "adopt", syncing its teams and keeping its console password
+func TestVMCreate_OnConflict(t *testing.T) {
+	for _, onConflict := range []string{onConflictAdopt, onConflictError} {
+		t.Run(onConflict, func(t *testing.T) {
+			ctx := context.Background()
+
+			var requests []string
+			var updated structs.VMInfo
+			r := &vmResource{client: newFakeAPIClient(t, func(w http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+
+				switch req.Method + " " + req.URL.Path {
+				case "POST /api/vms":
+					w.WriteHeader(http.StatusConflict)
+				case "GET /api/vms/vm-1":
+					json.NewEncoder(w).Encode(structs.VMInfo{
+						ID:         "vm-1",
+						Name:       "Old Name",
+						DefaultURL: true,
+						TeamIDs:    []string{"team-old"},
+						Connection: &structs.ConsoleConnection{Hostname: "old.example.local", Protocol: "ssh", Password: "current"},
+					})
+				case "PUT /api/vms/vm-1":
+					json.NewDecoder(req.Body).Decode(&updated)
+					w.Write([]byte("{}"))
+				case "POST /api/teams/team-new/vms/vm-1":
+					w.Write([]byte("{}"))
+				default:
+					w.WriteHeader(http.StatusNoContent)
+				}
+			})}
+
+			state := emptyState(ctx, r)
+			plan := tfsdk.Plan{Schema: state.Schema, Raw: state.Raw}
+			plan.SetAttribute(ctx, path.Root("vm_id"), "vm-1")
+			plan.SetAttribute(ctx, path.Root("name"), "VM")
+			plan.SetAttribute(ctx, path.Root("team_ids"), []string{"team-new"})
+			plan.SetAttribute(ctx, path.Root("on_conflict"), onConflict)
+			plan.SetAttribute(ctx, path.Root("console_connection_info"), consoleConnectionModel{
+				Hostname:          types.StringValue("vm1.example.local"),
+				Port:              types.StringNull(),
+				Protocol:          types.StringValue("ssh"),
+				Username:          types.StringNull(),
+				Password:          types.StringNull(),
+				PasswordWO:        types.StringNull(),
+				PasswordWOVersion: types.Int64Null(),
+			})
+			config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+
+			resp := &fwresource.CreateResponse{State: state}
+			r.Create(ctx, fwresource.CreateRequest{Plan: plan, Config: config}, resp)
+
+			if onConflict == onConflictError {
+				if !resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+					t.Errorf("Expected an error and no state, got: %v", resp.Diagnostics)
+				}
+				if len(requests) != 1 {
+					t.Errorf("Expected only the create request, got requests %v", requests)
+				}
+				return
+			}
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+			}
+			expected := []string{
+				"POST /api/vms",
+				"GET /api/vms/vm-1",
+				"DELETE /api/teams/team-old/vms/vm-1",
+				"POST /api/teams/team-new/vms/vm-1",
+				"PUT /api/vms/vm-1",
+			}
+			if fmt.Sprint(requests) != fmt.Sprint(expected) {
+				t.Errorf("Expected requests %v, got %v", expected, requests)
+			}
+			if updated.Name != "VM" || updated.Connection == nil || updated.Connection.Hostname != "vm1.example.local" || updated.Connection.Password != "current" {
+				t.Errorf("Expected the VM to be updated keeping its console password, got %+v", updated)
+			}
+
+			var defaultURL types.Bool
+			resp.State.GetAttribute(ctx, path.Root("default_url"), &defaultURL)
+			if !defaultURL.ValueBool() {
+				t.Errorf("Expected default_url from the existing VM, got %s", defaultURL)
+			}
+		})
+	}
+}
+
+// TestVMConfigValidators verifies which combinations of url, console_connection_info and proxmox_vm_info are rejected
+func TestVMConfigValidators(t *testing.T) {
+	ctx := context.Background()
+	r := &vmResource{}
+
+	console := consoleConnectionModel{
+		Hostname:          types.StringValue("vm1.example.local"),
+		Port:              types.StringNull(),
+		Protocol:          types.StringValue("ssh"),
+		Username:          types.StringNull(),
+		Password:          types.StringNull(),
+		PasswordWO:        types.StringNull(),
+		PasswordWOVersion: types.Int64Null(),
+	}
+	proxmox := proxmoxInfoModel{
+		ID:   types.StringValue("100"),
+		Node: types.StringValue("pve"),
+		Type: types.StringValue("QEMU"),
+	}
+
+	tests := []struct {
+		name        string
+		url         bool
+		console     bool
+		proxmox     bool
+		expectError bool
+	}{
+		{"none", false, false, false, false},
+		{"url", true, false, false, false},
+		{"console", false, true, false, false},
+		{"proxmox", false, false, true, false},
+		{"url and console", true, true, false, false},
+		{"url and proxmox", true, false, true, true},
+		{"console and proxmox", false, true, true, true},
+		{"all", true, true, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := emptyState(ctx, r)
+			config.SetAttribute(ctx, path.Root("name"), "User1")
+			if test.url {
+				config.SetAttribute(ctx, path.Root("url"), "https://guac.example.com/guacamole")
+			}
+			if test.console {
+				config.SetAttribute(ctx, path.Root("console_connection_info"), console)
+			}
+			if test.proxmox {
+				config.SetAttribute(ctx, path.Root("proxmox_vm_info"), proxmox)
+			}
+
+			// Each validator gets its own response, as in the framework
+			req := fwresource.ValidateConfigRequest{Config: tfsdk.Config{Schema: config.Schema, Raw: config.Raw}}
+			var diags diag.Diagnostics
+			for _, validator := range r.ConfigValidators(ctx) {
+				resp := &fwresource.ValidateConfigResponse{}
+				validator.ValidateResource(ctx, req, resp)
+				diags.Append(resp.Diagnostics...)
+			}
+
+			if diags.HasError() != test.expectError {
+				t.Errorf("Expected error = %v, got: %v", test.expectError, diags)
+			}
+			if test.expectError && diags.Errors()[0].Summary() != "Invalid Attribute Combination" {
+				t.Errorf("Expected an attribute combination error, got: %v", diags)
+			}
+		})
+	}
+}
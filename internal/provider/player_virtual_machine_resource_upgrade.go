@@ -0,0 +1,94 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.ResourceWithUpgradeState = &vmResource{}
+	_ resource.ResourceWithMoveState    = &vmResource{}
+)
+
+// UpgradeState upgrades state written by earlier versions of the VM schema.
+func (r *vmResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeVMState},
+		1: {StateUpgrader: upgradeVMState},
+	}
+}
+
+// MoveState accepts VM state written under another
+// Crucible provider address or a former type name.
+func (r *vmResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		moveStateFromCrucible("crucible_player_virtual_machine", formerTypeNames["crucible_player_virtual_machine"], r.UpgradeState(ctx)),
+	}
+}
+
+// upgradeVMState converts version 0 and 1 state to the current schema.
+// Version 0 was written either by the SDK releases or with team_ids as a list.
+// The SDK stored console_connection_info and proxmox_vm_info as lists of one
+// element and the Proxmox id as a number. Version 1 state already has the
+// current form and is copied as is.
+func upgradeVMState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	prior := newPriorState(req.RawState, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	teamIDs, diags := types.ListValueFrom(ctx, types.StringType, prior.Strings("team_ids"))
+	resp.Diagnostics.Append(diags...)
+	teamIDSet, diags := listToSet(ctx, teamIDs)
+	resp.Diagnostics.Append(diags...)
+
+	console := types.ObjectNull(consoleConnectionAttrTypes())
+	if info, ok := prior.Object("console_connection_info"); ok {
+		console, diags = types.ObjectValueFrom(ctx, consoleConnectionAttrTypes(), consoleConnectionModel{
+			Hostname:          info.String("hostname"),
+			Port:              info.String("port"),
+			Protocol:          info.String("protocol"),
+			Username:          info.String("username"),
+			Password:          info.String("password"),
+			PasswordWO:        types.StringNull(),
+			PasswordWOVersion: info.Int64("password_wo_version"),
+		})
+		resp.Diagnostics.Append(diags...)
+	}
+
+	proxmox := types.ObjectNull(proxmoxInfoAttrTypes())
+	if info, ok := prior.Object("proxmox_vm_info"); ok {
+		proxmox, diags = types.ObjectValueFrom(ctx, proxmoxInfoAttrTypes(), proxmoxInfoModel{
+			ID:   info.String("id"),
+			Node: info.String("node"),
+			Type: info.String("type"),
+		})
+		resp.Diagnostics.Append(diags...)
+	}
+
+	upgraded := vmResourceModel{
+		ID:                 prior.String("id"),
+		VMID:               prior.String("vm_id"),
+		URL:                prior.String("url"),
+		DefaultURL:         prior.Bool("default_url"),
+		Name:               prior.String("name"),
+		TeamIDs:            teamIDSet,
+		UserID:             prior.String("user_id"),
+		Embeddable:         prior.Bool("embeddable"),
+		ConsoleConnection:  console,
+		ProxmoxInfo:        proxmox,
+		OnConflict:         onConflictOrDefault(prior.String("on_conflict")),
+		DeletionProtection: boolOrDefault(prior.Bool("deletion_protection"), false),
+		Timeouts:           prior.Timeouts("create", "read", "update", "delete"),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, upgraded)...)
+}
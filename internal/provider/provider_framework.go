@@ -6,18 +6,38 @@ package provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
-var _ provider.Provider = &crucibleProvider{}
+var (
+	_ provider.Provider                       = &crucibleProvider{}
+	_ provider.ProviderWithListResources      = &crucibleProvider{}
+	_ provider.ProviderWithEphemeralResources = &crucibleProvider{}
+	_ provider.ProviderWithFunctions          = &crucibleProvider{}
+)
 
 // crucibleProvider is the provider implementation.
 type crucibleProvider struct {
@@ -26,16 +46,38 @@ type crucibleProvider struct {
 
 // crucibleProviderModel describes the provider configuration data model.
 type crucibleProviderModel struct {
-	Username      types.String `tfsdk:"username"`
-	Password      types.String `tfsdk:"password"`
-	AuthURL       types.String `tfsdk:"auth_url"`
-	TokenURL      types.String `tfsdk:"token_url"`
-	VMApiURL      types.String `tfsdk:"vm_api_url"`
-	PlayerApiURL  types.String `tfsdk:"player_api_url"`
-	CasterApiURL  types.String `tfsdk:"caster_api_url"`
-	ClientID      types.String `tfsdk:"client_id"`
-	ClientSecret  types.String `tfsdk:"client_secret"`
-	ClientScopes  types.String `tfsdk:"client_scopes"`
+	Username                  types.String  `tfsdk:"username"`
+	Password                  types.String  `tfsdk:"password"`
+	AuthURL                   types.String  `tfsdk:"auth_url"`
+	TokenURL                  types.String  `tfsdk:"token_url"`
+	VMApiURL                  types.String  `tfsdk:"vm_api_url"`
+	PlayerApiURL              types.String  `tfsdk:"player_api_url"`
+	CasterApiURL              types.String  `tfsdk:"caster_api_url"`
+	ClientID                  types.String  `tfsdk:"client_id"`
+	ClientSecret              types.String  `tfsdk:"client_secret"`
+	ClientScopes              types.Dynamic `tfsdk:"client_scopes"`
+	CACertFile                types.String  `tfsdk:"ca_cert_file"`
+	CACertPEM                 types.String  `tfsdk:"ca_cert_pem"`
+	ClientCert                types.String  `tfsdk:"client_cert"`
+	ClientKey                 types.String  `tfsdk:"client_key"`
+	InsecureSkipVerify        types.Bool    `tfsdk:"insecure_skip_verify"`
+	HTTPProxy                 types.String  `tfsdk:"http_proxy"`
+	RequestTimeout            types.String  `tfsdk:"request_timeout"`
+	SkipCredentialsValidation types.Bool    `tfsdk:"skip_credentials_validation"`
+	DisableLookupCache        types.Bool    `tfsdk:"disable_lookup_cache"`
+	MaxParallelRequests       types.Int64   `tfsdk:"max_parallel_requests"`
+	Player                    types.Object  `tfsdk:"player"`
+	VM                        types.Object  `tfsdk:"vm"`
+	Caster                    types.Object  `tfsdk:"caster"`
+}
+
+// serviceCredentialsModel describes a per-API credentials block.
+type serviceCredentialsModel struct {
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	ClientScopes types.List   `tfsdk:"client_scopes"`
 }
 
 // New returns a configured provider instance.
@@ -59,46 +101,127 @@ func (p *crucibleProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 		Description: "Terraform provider for managing Crucible Framework resources (Player, VM, and Caster APIs).",
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "Username for OAuth2 authentication. Can be set via SEI_CRUCIBLE_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				Description: "Password for OAuth2 authentication. Can be set via SEI_CRUCIBLE_PASSWORD environment variable.",
 			},
 			"auth_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "OAuth2 authorization endpoint URL. Can be set via SEI_CRUCIBLE_AUTH_URL environment variable.",
 			},
 			"token_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "OAuth2 token endpoint URL. Can be set via SEI_CRUCIBLE_TOKEN_URL environment variable (or legacy SEI_CRUCIBLE_TOK_URL).",
 			},
 			"vm_api_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "Base URL for the VM API. Can be set via SEI_CRUCIBLE_VM_API_URL environment variable.",
 			},
 			"player_api_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "Base URL for the Player API. Can be set via SEI_CRUCIBLE_PLAYER_API_URL environment variable.",
 			},
 			"caster_api_url": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "Base URL for the Caster API. Can be set via SEI_CRUCIBLE_CASTER_API_URL environment variable.",
 			},
 			"client_id": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Description: "OAuth2 client ID. Can be set via SEI_CRUCIBLE_CLIENT_ID environment variable.",
 			},
 			"client_secret": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
 				Sensitive:   true,
 				Description: "OAuth2 client secret. Can be set via SEI_CRUCIBLE_CLIENT_SECRET environment variable.",
 			},
-			"client_scopes": schema.StringAttribute{
-				Required:    true,
-				Description: "OAuth2 client scopes as JSON array (e.g., '[\"player-api\",\"vm-api\"]'). Can be set via SEI_CRUCIBLE_CLIENT_SCOPES environment variable.",
+			"client_scopes": schema.DynamicAttribute{
+				Optional:    true,
+				Description: "OAuth2 client scopes as a list of strings (e.g., [\"player-api\", \"vm-api\"]). A JSON-encoded string is still accepted but deprecated. Can be set via SEI_CRUCIBLE_CLIENT_SCOPES environment variable as a comma- or space-separated list.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM-encoded CA bundle trusted in addition to the system roots. Can be set via SEI_CRUCIBLE_CA_CERT_FILE environment variable.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM-encoded CA certificates trusted in addition to the system roots. Can be set via SEI_CRUCIBLE_CA_CERT_PEM environment variable.",
+			},
+			"client_cert": schema.StringAttribute{
+				Optional:    true,
+				Description: "Client certificate for mutual TLS, as PEM content or a path to a PEM file. Requires client_key. Can be set via SEI_CRUCIBLE_CLIENT_CERT environment variable.",
+			},
+			"client_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Private key for the mutual TLS client certificate, as PEM content or a path to a PEM file. Can be set via SEI_CRUCIBLE_CLIENT_KEY environment variable.",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable TLS certificate verification. Intended for testing only. Can be set via SEI_CRUCIBLE_INSECURE_SKIP_VERIFY environment variable.",
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of a proxy for all API and token requests. If unset, the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored. Can be set via SEI_CRUCIBLE_HTTP_PROXY environment variable.",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Timeout for each HTTP request as a duration string (e.g., \"30s\", \"2m\"). If unset, requests made by a resource are bounded by its operation timeout (see the timeouts block) and other requests time out after 30s. Can be set via SEI_CRUCIBLE_REQUEST_TIMEOUT environment variable.",
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip fetching a token while configuring the provider. Authentication then happens on the first API request. Can be set via SEI_CRUCIBLE_SKIP_CREDENTIALS_VALIDATION environment variable.",
+			},
+			"disable_lookup_cache": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Disable caching of permission and role lookups. By default each permission or role name is resolved once per run. Can be set via SEI_CRUCIBLE_DISABLE_LOOKUP_CACHE environment variable.",
+			},
+			"max_parallel_requests": schema.Int64Attribute{
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				Description: "Maximum number of concurrent API requests made while creating the teams, users and application instances of each view. Defaults to 4. Can be set via SEI_CRUCIBLE_MAX_PARALLEL_REQUESTS environment variable.",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"player": serviceCredentialsBlock("Player API"),
+			"vm":     serviceCredentialsBlock("VM API"),
+			"caster": serviceCredentialsBlock("Caster API"),
+		},
+	}
+}
+
+// serviceCredentialsBlock returns the schema for a per-API credentials block.
+func serviceCredentialsBlock(apiName string) schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: fmt.Sprintf("Optional OAuth2 credentials used only for the %s, for deployments where it is registered as a separate audience. Unset attributes fall back to the provider-level values, and a separate token is cached for this API.", apiName),
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Username for OAuth2 authentication with the %s.", apiName),
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("Password for OAuth2 authentication with the %s.", apiName),
+			},
+			"client_id": schema.StringAttribute{
+				Optional:    true,
+				Description: fmt.Sprintf("OAuth2 client ID for the %s.", apiName),
+			},
+			"client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: fmt.Sprintf("OAuth2 client secret for the %s.", apiName),
+			},
+			"client_scopes": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: fmt.Sprintf("OAuth2 client scopes requested for the %s.", apiName),
 			},
 		},
 	}
@@ -114,103 +237,153 @@ func (p *crucibleProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
-	// Allow environment variables to override config
-	if val := os.Getenv("SEI_CRUCIBLE_USERNAME"); val != "" {
-		config.Username = types.StringValue(val)
-	}
-	if val := os.Getenv("SEI_CRUCIBLE_PASSWORD"); val != "" {
-		config.Password = types.StringValue(val)
-	}
-	if val := os.Getenv("SEI_CRUCIBLE_AUTH_URL"); val != "" {
-		config.AuthURL = types.StringValue(val)
-	}
-	// Support both new and legacy environment variable names
-	if val := os.Getenv("SEI_CRUCIBLE_TOKEN_URL"); val != "" {
-		config.TokenURL = types.StringValue(val)
-	} else if val := os.Getenv("SEI_CRUCIBLE_TOK_URL"); val != "" {
-		config.TokenURL = types.StringValue(val)
-	}
-	if val := os.Getenv("SEI_CRUCIBLE_VM_API_URL"); val != "" {
-		config.VMApiURL = types.StringValue(val)
-	}
-	if val := os.Getenv("SEI_CRUCIBLE_PLAYER_API_URL"); val != "" {
-		config.PlayerApiURL = types.StringValue(val)
+	// Build provider configuration. Values set in the provider block take
+	// precedence; environment variables are only used for unset attributes.
+	providerConfig := &client.ProviderConfig{
+		Username:     stringValueOrEnv(config.Username, "SEI_CRUCIBLE_USERNAME"),
+		Password:     stringValueOrEnv(config.Password, "SEI_CRUCIBLE_PASSWORD"),
+		AuthURL:      stringValueOrEnv(config.AuthURL, "SEI_CRUCIBLE_AUTH_URL"),
+		TokenURL:     stringValueOrEnv(config.TokenURL, "SEI_CRUCIBLE_TOKEN_URL", "SEI_CRUCIBLE_TOK_URL"),
+		VMApiURL:     stringValueOrEnv(config.VMApiURL, "SEI_CRUCIBLE_VM_API_URL"),
+		PlayerApiURL: stringValueOrEnv(config.PlayerApiURL, "SEI_CRUCIBLE_PLAYER_API_URL"),
+		CasterApiURL: stringValueOrEnv(config.CasterApiURL, "SEI_CRUCIBLE_CASTER_API_URL"),
+		ClientID:     stringValueOrEnv(config.ClientID, "SEI_CRUCIBLE_CLIENT_ID"),
+		ClientSecret: stringValueOrEnv(config.ClientSecret, "SEI_CRUCIBLE_CLIENT_SECRET"),
 	}
-	if val := os.Getenv("SEI_CRUCIBLE_CASTER_API_URL"); val != "" {
-		config.CasterApiURL = types.StringValue(val)
+
+	// Provider configuration may reference values that are not known until apply,
+	// such as credentials for a client created in the same run. In that case skip
+	// validation and let the client authenticate on its first request.
+	configKnown := req.Config.Raw.IsFullyKnown()
+	if !configKnown {
+		tflog.Debug(ctx, "Provider configuration contains unknown values; deferring validation and authentication")
 	}
-	if val := os.Getenv("SEI_CRUCIBLE_CLIENT_ID"); val != "" {
-		config.ClientID = types.StringValue(val)
+
+	// Validate required configuration, reporting every missing setting at once
+	if missing := missingProviderSettings(providerConfig); configKnown && len(missing) > 0 {
+		resp.Diagnostics.AddError(
+			"Missing Provider Configuration",
+			"The provider is missing required configuration. Set the following attributes in the provider block "+
+				"or their corresponding environment variables:\n\n  - "+strings.Join(missing, "\n  - "),
+		)
+		return
 	}
-	if val := os.Getenv("SEI_CRUCIBLE_CLIENT_SECRET"); val != "" {
-		config.ClientSecret = types.StringValue(val)
+
+	// Resolve client scopes from configuration or environment
+	scopes, diags := clientScopesOrEnv(ctx, config.ClientScopes, "SEI_CRUCIBLE_CLIENT_SCOPES")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if val := os.Getenv("SEI_CRUCIBLE_CLIENT_SCOPES"); val != "" {
-		config.ClientScopes = types.StringValue(val)
+	providerConfig.ClientScopes = scopes
+
+	// Resolve optional per-API credentials
+	providerConfig.Player = serviceCredentials(ctx, config.Player, &resp.Diagnostics)
+	providerConfig.VM = serviceCredentials(ctx, config.VM, &resp.Diagnostics)
+	providerConfig.Caster = serviceCredentials(ctx, config.Caster, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	// Validate required configuration
-	if config.Username.IsNull() || config.Username.ValueString() == "" {
-		resp.Diagnostics.AddError(
-			"Missing Username Configuration",
-			"The provider requires a username. Set the 'username' attribute or SEI_CRUCIBLE_USERNAME environment variable.",
-		)
+	// Build the HTTP client used for API calls and the token exchange
+	transportConfig := transportConfigFromModel(config, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if config.Password.IsNull() || config.Password.ValueString() == "" {
-		resp.Diagnostics.AddError(
-			"Missing Password Configuration",
-			"The provider requires a password. Set the 'password' attribute or SEI_CRUCIBLE_PASSWORD environment variable.",
+
+	if transportConfig.InsecureSkipVerify {
+		resp.Diagnostics.AddWarning(
+			"TLS Certificate Verification Disabled",
+			"insecure_skip_verify is enabled, so the provider will not verify the certificates presented by the Crucible APIs or the identity provider. Do not use this setting in production.",
 		)
 	}
-	if config.TokenURL.IsNull() || config.TokenURL.ValueString() == "" {
+
+	httpClient, err := client.NewHTTPClient(transportConfig)
+	if err != nil {
 		resp.Diagnostics.AddError(
-			"Missing Token URL Configuration",
-			"The provider requires a token URL. Set the 'token_url' attribute or SEI_CRUCIBLE_TOKEN_URL environment variable.",
+			"Invalid HTTP Client Configuration",
+			fmt.Sprintf("Could not configure the HTTP client: %s", err.Error()),
 		)
-	}
-
-	if resp.Diagnostics.HasError() {
 		return
 	}
+	providerConfig.HTTPClient = httpClient
 
-	// Parse client scopes from JSON array string
-	var scopes []string
-	scopesStr := config.ClientScopes.ValueString()
-	if scopesStr != "" {
-		if err := json.Unmarshal([]byte(scopesStr), &scopes); err != nil {
-			// Try comma-separated format as fallback
-			scopes = []string{scopesStr}
-		}
+	disableLookupCache, err := boolValueOrEnv(config.DisableLookupCache, "SEI_CRUCIBLE_DISABLE_LOOKUP_CACHE")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disable_lookup_cache"),
+			"Invalid Disable Lookup Cache Setting",
+			fmt.Sprintf("Could not parse SEI_CRUCIBLE_DISABLE_LOOKUP_CACHE as a boolean: %s", err.Error()),
+		)
+		return
 	}
+	providerConfig.DisableLookupCache = disableLookupCache
 
-	// Build provider configuration
-	providerConfig := &client.ProviderConfig{
-		Username:      config.Username.ValueString(),
-		Password:      config.Password.ValueString(),
-		AuthURL:       config.AuthURL.ValueString(),
-		TokenURL:      config.TokenURL.ValueString(),
-		VMApiURL:      config.VMApiURL.ValueString(),
-		PlayerApiURL:  config.PlayerApiURL.ValueString(),
-		CasterApiURL:  config.CasterApiURL.ValueString(),
-		ClientID:      config.ClientID.ValueString(),
-		ClientSecret:  config.ClientSecret.ValueString(),
-		ClientScopes:  scopes,
+	maxParallelRequests, err := int64ValueOrEnv(config.MaxParallelRequests, "SEI_CRUCIBLE_MAX_PARALLEL_REQUESTS")
+	if err != nil || maxParallelRequests < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_parallel_requests"),
+			"Invalid Max Parallel Requests",
+			"Could not parse SEI_CRUCIBLE_MAX_PARALLEL_REQUESTS as a positive integer.",
+		)
+		return
 	}
+	providerConfig.MaxParallelRequests = int(maxParallelRequests)
+	providerConfig.ConfigUnknown = !configKnown
 
 	// Create client
 	crucibleClient := client.NewClient(providerConfig)
 
-	// Test authentication by fetching a token
-	if _, err := crucibleClient.GetToken(ctx); err != nil {
-		resp.Diagnostics.AddError(
-			"Authentication Failed",
-			fmt.Sprintf("Could not authenticate with Crucible APIs: %s\n\nVerify your credentials and token URL are correct.", err.Error()),
+	skipValidation, err := boolValueOrEnv(config.SkipCredentialsValidation, "SEI_CRUCIBLE_SKIP_CREDENTIALS_VALIDATION")
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("skip_credentials_validation"),
+			"Invalid Skip Credentials Validation Setting",
+			fmt.Sprintf("Could not parse SEI_CRUCIBLE_SKIP_CREDENTIALS_VALIDATION as a boolean: %s", err.Error()),
 		)
 		return
 	}
 
+	// Test authentication by fetching a token. The client otherwise authenticates
+	// lazily on its first request.
+	if configKnown && !skipValidation {
+		if _, err := crucibleClient.GetToken(ctx); err != nil {
+			resp.Diagnostics.AddError(
+				"Authentication Failed",
+				fmt.Sprintf("Could not authenticate with Crucible APIs: %s\n\nVerify your credentials and token URL are correct, or set skip_credentials_validation to defer authentication.", err.Error()),
+			)
+			return
+		}
+	}
+
+	// Terraform versions that support deferred actions plan resources again once
+	// the configuration is known. Other versions call resources with this
+	// client, which keep their prior state rather than calling the APIs.
+	if !configKnown && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+	}
+
 	// Make client available to resources
 	resp.ResourceData = crucibleClient
+	resp.ListResourceData = crucibleClient
+	resp.EphemeralResourceData = crucibleClient
+}
+
+// requireKnownConfig reports whether c, the client given to a resource, was
+// built from fully known provider configuration, adding an error to diags if
+// it was not. It guards operations that have no prior state to keep, such as
+// imports by name and list queries.
+func requireKnownConfig(c *client.CrucibleClient, diags *diag.Diagnostics) bool {
+	if c.ConfigKnown() {
+		return true
+	}
+
+	diags.AddError(
+		"Provider Configuration Unknown",
+		"The Crucible provider configuration contains values that are not known yet, such as attributes of resources that have not been created, "+
+			"so objects cannot be looked up. Apply the resources the provider configuration depends on first, then run Terraform again.",
+	)
+	return false
 }
 
 // Resources returns the list of resources supported by this provider.
@@ -224,9 +397,271 @@ func (p *crucibleProvider) Resources(_ context.Context) []func() resource.Resour
 	}
 }
 
+// ListResources returns the list resources supported by this provider, which
+// let terraform query discover existing objects to import.
+func (p *crucibleProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewPlayerUserListResource,
+		NewAppTemplateListResource,
+		NewVlanListResource,
+		NewVMListResource,
+		NewViewListResource,
+	}
+}
+
+// EphemeralResources returns the ephemeral resources supported by this provider.
+func (p *crucibleProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAccessTokenEphemeralResource,
+	}
+}
+
+// Functions returns the provider-defined functions supported by this provider.
+func (p *crucibleProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseProxmoxIDFunction,
+		NewPlayerViewURLFunction,
+		NewVMConsoleURLFunction,
+		NewNormalizeAPIURLFunction,
+	}
+}
+
 // DataSources returns the list of data sources supported by this provider.
 func (p *crucibleProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		// No data sources currently implemented
 	}
 }
+
+// stringValueOrEnv returns the configured value of an attribute, falling back to
+// the first non-empty environment variable in envVars when the attribute is unset.
+func stringValueOrEnv(value types.String, envVars ...string) string {
+	if !value.IsNull() && !value.IsUnknown() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+
+	for _, envVar := range envVars {
+		if val := os.Getenv(envVar); val != "" {
+			return val
+		}
+	}
+
+	return ""
+}
+
+// clientScopesOrEnv resolves the client_scopes attribute, which may be a list of
+// strings or a legacy JSON-encoded string, falling back to envVar when unset.
+// The environment variable accepts a comma- or space-separated list, or a legacy
+// JSON array.
+func clientScopesOrEnv(ctx context.Context, value types.Dynamic, envVar string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if value.IsNull() || value.IsUnknown() || value.IsUnderlyingValueNull() || value.IsUnderlyingValueUnknown() {
+		envVal := os.Getenv(envVar)
+		if envVal == "" {
+			return nil, diags
+		}
+
+		scopes, legacy, err := parseClientScopes(envVal)
+		if err != nil {
+			diags.AddError(
+				"Invalid Client Scopes",
+				fmt.Sprintf("Could not parse %s: %s", envVar, err.Error()),
+			)
+			return nil, diags
+		}
+		if legacy {
+			diags.AddWarning(
+				"Deprecated Client Scopes Format",
+				fmt.Sprintf("%s is set to a JSON-encoded array. This format is deprecated; use a comma- or space-separated list instead (e.g., \"player-api vm-api\").", envVar),
+			)
+		}
+		return scopes, diags
+	}
+
+	var elements []attr.Value
+	switch underlying := value.UnderlyingValue().(type) {
+	case types.String:
+		scopes, _, err := parseClientScopes(underlying.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("client_scopes"),
+				"Invalid Client Scopes",
+				fmt.Sprintf("Could not parse client_scopes: %s", err.Error()),
+			)
+			return nil, diags
+		}
+		diags.AddAttributeWarning(
+			path.Root("client_scopes"),
+			"Deprecated Client Scopes Format",
+			"Setting client_scopes to a string is deprecated. Use a list of strings instead (e.g., client_scopes = [\"player-api\", \"vm-api\"]).",
+		)
+		return scopes, diags
+	case types.List:
+		elements = underlying.Elements()
+	case types.Tuple:
+		elements = underlying.Elements()
+	case types.Set:
+		elements = underlying.Elements()
+	default:
+		diags.AddAttributeError(
+			path.Root("client_scopes"),
+			"Invalid Client Scopes",
+			fmt.Sprintf("client_scopes must be a list of strings, got: %s", value.UnderlyingValue().Type(ctx)),
+		)
+		return nil, diags
+	}
+
+	scopes := make([]string, 0, len(elements))
+	for _, element := range elements {
+		scope, ok := element.(types.String)
+		if !ok || scope.IsNull() || scope.IsUnknown() {
+			diags.AddAttributeError(
+				path.Root("client_scopes"),
+				"Invalid Client Scopes",
+				"client_scopes must only contain known, non-null strings.",
+			)
+			return nil, diags
+		}
+		if scope.ValueString() != "" {
+			scopes = append(scopes, scope.ValueString())
+		}
+	}
+
+	return scopes, diags
+}
+
+// parseClientScopes parses a string of client scopes. JSON arrays are treated as
+// the legacy format; anything else is split on commas and whitespace.
+func parseClientScopes(value string) (scopes []string, legacy bool, err error) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") {
+		if err := json.Unmarshal([]byte(value), &scopes); err != nil {
+			return nil, true, fmt.Errorf("invalid JSON array: %w", err)
+		}
+		return scopes, true, nil
+	}
+
+	scopes = strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+	return scopes, false, nil
+}
+
+// serviceCredentials converts a per-API credentials block into client
+// credentials. It returns nil when the block is not configured.
+func serviceCredentials(ctx context.Context, value types.Object, diags *diag.Diagnostics) *client.Credentials {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	var model serviceCredentialsModel
+	diags.Append(value.As(ctx, &model, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil
+	}
+
+	creds := &client.Credentials{
+		Username:     model.Username.ValueString(),
+		Password:     model.Password.ValueString(),
+		ClientID:     model.ClientID.ValueString(),
+		ClientSecret: model.ClientSecret.ValueString(),
+	}
+
+	if !model.ClientScopes.IsNull() && !model.ClientScopes.IsUnknown() {
+		diags.Append(model.ClientScopes.ElementsAs(ctx, &creds.ClientScopes, false)...)
+	}
+
+	return creds
+}
+
+// transportConfigFromModel resolves the TLS, proxy and timeout settings from
+// configuration or environment.
+func transportConfigFromModel(config crucibleProviderModel, diags *diag.Diagnostics) client.TransportConfig {
+	transportConfig := client.TransportConfig{
+		CACertFile: stringValueOrEnv(config.CACertFile, "SEI_CRUCIBLE_CA_CERT_FILE"),
+		CACertPEM:  stringValueOrEnv(config.CACertPEM, "SEI_CRUCIBLE_CA_CERT_PEM"),
+		ClientCert: stringValueOrEnv(config.ClientCert, "SEI_CRUCIBLE_CLIENT_CERT"),
+		ClientKey:  stringValueOrEnv(config.ClientKey, "SEI_CRUCIBLE_CLIENT_KEY"),
+		HTTPProxy:  stringValueOrEnv(config.HTTPProxy, "SEI_CRUCIBLE_HTTP_PROXY"),
+	}
+
+	insecure, err := boolValueOrEnv(config.InsecureSkipVerify, "SEI_CRUCIBLE_INSECURE_SKIP_VERIFY")
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Invalid Insecure Skip Verify Setting",
+			fmt.Sprintf("Could not parse SEI_CRUCIBLE_INSECURE_SKIP_VERIFY as a boolean: %s", err.Error()),
+		)
+	}
+	transportConfig.InsecureSkipVerify = insecure
+
+	if timeout := stringValueOrEnv(config.RequestTimeout, "SEI_CRUCIBLE_REQUEST_TIMEOUT"); timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil || duration <= 0 {
+			diags.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Request Timeout",
+				fmt.Sprintf("request_timeout must be a positive duration such as \"30s\" or \"2m\", got: %q", timeout),
+			)
+		}
+		transportConfig.RequestTimeout = duration
+	}
+
+	return transportConfig
+}
+
+// boolValueOrEnv returns the configured value of a boolean attribute, falling
+// back to envVar when the attribute is unset.
+func boolValueOrEnv(value types.Bool, envVar string) (bool, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueBool(), nil
+	}
+
+	if val := os.Getenv(envVar); val != "" {
+		return strconv.ParseBool(val)
+	}
+
+	return false, nil
+}
+
+// int64ValueOrEnv returns the configured value of an integer attribute, falling
+// back to envVar when the attribute is unset. Zero means neither is set.
+func int64ValueOrEnv(value types.Int64, envVar string) (int64, error) {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value.ValueInt64(), nil
+	}
+
+	if val := os.Getenv(envVar); val != "" {
+		return strconv.ParseInt(val, 10, 64)
+	}
+
+	return 0, nil
+}
+
+// missingProviderSettings returns a description of every required setting that
+// was supplied neither in configuration nor through the environment.
+func missingProviderSettings(config *client.ProviderConfig) []string {
+	required := []struct {
+		attribute string
+		envVar    string
+		value     string
+	}{
+		{"username", "SEI_CRUCIBLE_USERNAME", config.Username},
+		{"password", "SEI_CRUCIBLE_PASSWORD", config.Password},
+		{"token_url", "SEI_CRUCIBLE_TOKEN_URL", config.TokenURL},
+		{"client_id", "SEI_CRUCIBLE_CLIENT_ID", config.ClientID},
+		{"player_api_url", "SEI_CRUCIBLE_PLAYER_API_URL", config.PlayerApiURL},
+		{"vm_api_url", "SEI_CRUCIBLE_VM_API_URL", config.VMApiURL},
+		{"caster_api_url", "SEI_CRUCIBLE_CASTER_API_URL", config.CasterApiURL},
+	}
+
+	var missing []string
+	for _, setting := range required {
+		if setting.value == "" {
+			missing = append(missing, fmt.Sprintf("%s (%s)", setting.attribute, setting.envVar))
+		}
+	}
+
+	return missing
+}
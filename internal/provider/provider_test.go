@@ -5,11 +5,22 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 
+	"github.com/cmu-sei/terraform-provider-crucible/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -20,6 +31,37 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"crucible": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+// newFakeAPIClient returns a client whose token endpoint and Player, VM and
+// Caster APIs are served by a test server. Requests other than token requests
+// are passed to handler.
+func newFakeAPIClient(t *testing.T, handler http.HandlerFunc) *client.CrucibleClient {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "test-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return client.NewClient(&client.ProviderConfig{
+		Username:     "test-user",
+		Password:     "test-pass",
+		TokenURL:     server.URL + "/token",
+		ClientID:     "test-client",
+		PlayerApiURL: server.URL,
+		VMApiURL:     server.URL,
+		CasterApiURL: server.URL,
+	})
+}
+
 // testAccPreCheck validates that required environment variables are set for acceptance tests
 func testAccPreCheck(t *testing.T) {
 	required := []string{
@@ -39,3 +81,152 @@ func testAccPreCheck(t *testing.T) {
 		}
 	}
 }
+
+// TestStringValueOrEnv verifies that configured values take precedence over environment variables
+func TestStringValueOrEnv(t *testing.T) {
+	t.Setenv("SEI_CRUCIBLE_TEST_PRIMARY", "")
+	t.Setenv("SEI_CRUCIBLE_TEST_LEGACY", "from-legacy-env")
+
+	if got := stringValueOrEnv(types.StringValue("from-config"), "SEI_CRUCIBLE_TEST_LEGACY"); got != "from-config" {
+		t.Errorf("Expected configured value to win over environment, got %q", got)
+	}
+
+	if got := stringValueOrEnv(types.StringNull(), "SEI_CRUCIBLE_TEST_PRIMARY", "SEI_CRUCIBLE_TEST_LEGACY"); got != "from-legacy-env" {
+		t.Errorf("Expected fallback to first non-empty environment variable, got %q", got)
+	}
+
+	if got := stringValueOrEnv(types.StringValue(""), "SEI_CRUCIBLE_TEST_PRIMARY"); got != "" {
+		t.Errorf("Expected empty value when nothing is set, got %q", got)
+	}
+}
+
+// TestMissingProviderSettings verifies that every missing required setting is reported
+func TestMissingProviderSettings(t *testing.T) {
+	missing := missingProviderSettings(&client.ProviderConfig{
+		Username:     "test-user",
+		TokenURL:     "https://id.example.com/token",
+		PlayerApiURL: "https://player.example.com",
+	})
+
+	expected := []string{
+		"password (SEI_CRUCIBLE_PASSWORD)",
+		"client_id (SEI_CRUCIBLE_CLIENT_ID)",
+		"vm_api_url (SEI_CRUCIBLE_VM_API_URL)",
+		"caster_api_url (SEI_CRUCIBLE_CASTER_API_URL)",
+	}
+
+	if strings.Join(missing, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected missing settings %v, got %v", expected, missing)
+	}
+}
+
+// TestParseClientScopes verifies parsing of separated and legacy JSON scope strings
+func TestParseClientScopes(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+		legacy   bool
+	}{
+		{"player-api vm-api", []string{"player-api", "vm-api"}, false},
+		{"player-api,vm-api, caster-api", []string{"player-api", "vm-api", "caster-api"}, false},
+		{`["player-api","vm-api"]`, []string{"player-api", "vm-api"}, true},
+		{"", []string{}, false},
+	}
+
+	for _, test := range tests {
+		scopes, legacy, err := parseClientScopes(test.input)
+		if err != nil {
+			t.Fatalf("parseClientScopes(%q) returned error: %v", test.input, err)
+		}
+		if strings.Join(scopes, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("parseClientScopes(%q) = %v, want %v", test.input, scopes, test.expected)
+		}
+		if legacy != test.legacy {
+			t.Errorf("parseClientScopes(%q) legacy = %v, want %v", test.input, legacy, test.legacy)
+		}
+	}
+
+	if _, _, err := parseClientScopes(`["player-api",`); err == nil {
+		t.Error("Expected error for malformed JSON array")
+	}
+}
+
+// TestConfigure_UnknownConfig verifies that unknown provider configuration
+// defers authentication, and that resources keep their prior state and import
+// by ID without calling the APIs
+func TestConfigure_UnknownConfig(t *testing.T) {
+	ctx := context.Background()
+	p := New("test")()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	// Every attribute is null except the password, which is not known until apply
+	objectType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(attrType, nil)
+	}
+	values["password"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	config := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, values)}
+
+	deferredResp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config:             config,
+		ClientCapabilities: provider.ConfigureProviderClientCapabilities{DeferralAllowed: true},
+	}, deferredResp)
+	if deferredResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", deferredResp.Diagnostics)
+	}
+	if deferredResp.Deferred == nil || deferredResp.Deferred.Reason != provider.DeferredReasonProviderConfigUnknown {
+		t.Errorf("Expected a deferral because the provider configuration is unknown, got %v", deferredResp.Deferred)
+	}
+
+	resp := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors: %v", resp.Diagnostics)
+	}
+	if resp.Deferred != nil {
+		t.Errorf("Expected no deferral when Terraform does not support it, got %v", resp.Deferred)
+	}
+	crucibleClient, ok := resp.ResourceData.(*client.CrucibleClient)
+	if !ok || crucibleClient.ConfigKnown() {
+		t.Fatalf("Expected a client marked as built from unknown configuration, got %v", resp.ResourceData)
+	}
+
+	r := &viewResource{}
+	r.Configure(ctx, resource.ConfigureRequest{ProviderData: resp.ResourceData}, &resource.ConfigureResponse{})
+
+	// The client has no API URLs, so any request would fail
+	state := emptyState(ctx, r)
+	state.SetAttribute(ctx, path.Root("id"), "view-1")
+	state.SetAttribute(ctx, path.Root("name"), "Exercise")
+	readResp := &resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors from Read: %v", readResp.Diagnostics)
+	}
+	var name types.String
+	readResp.State.GetAttribute(ctx, path.Root("name"), &name)
+	if name.ValueString() != "Exercise" {
+		t.Errorf("Expected Read to keep the prior state, got name %s", name)
+	}
+
+	importResp := &resource.ImportStateResponse{State: emptyState(ctx, r)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "view-2"}, importResp)
+	if importResp.Diagnostics.HasError() {
+		t.Fatalf("Unexpected errors importing by ID: %v", importResp.Diagnostics)
+	}
+	var id types.String
+	importResp.State.GetAttribute(ctx, path.Root("id"), &id)
+	if id.ValueString() != "view-2" {
+		t.Errorf("Expected imported id view-2, got %s", id)
+	}
+
+	importResp = &resource.ImportStateResponse{State: emptyState(ctx, r)}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "name:Exercise"}, importResp)
+	if !importResp.Diagnostics.HasError() || importResp.Diagnostics.Errors()[0].Summary() != "Provider Configuration Unknown" {
+		t.Errorf("Expected import by name to report unknown configuration, got: %v", importResp.Diagnostics)
+	}
+}
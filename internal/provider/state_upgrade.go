@@ -0,0 +1,355 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// listToSet converts a list from prior state into a set with the same element
+// type. Duplicate elements, which a set cannot hold, are dropped.
+func listToSet(ctx context.Context, list types.List) (types.Set, diag.Diagnostics) {
+	elemType := list.ElementType(ctx)
+
+	if list.IsNull() {
+		return types.SetNull(elemType), nil
+	}
+	if list.IsUnknown() {
+		return types.SetUnknown(elemType), nil
+	}
+
+	var elems []attr.Value
+	for _, elem := range list.Elements() {
+		duplicate := false
+		for _, existing := range elems {
+			if existing.Equal(elem) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			elems = append(elems, elem)
+		}
+	}
+
+	return types.SetValue(elemType, elems)
+}
+
+// priorState decodes the JSON of a prior resource state without a schema.
+//
+// Version 0 state was written either by the SDK-based v0.x releases or by the
+// first framework releases, and the two differ in ways no single schema can
+// describe: the SDK stored unset strings as "", some booleans as strings,
+// numbers and strings interchangeably, and single nested objects as lists of
+// one element. priorState accepts every form and reports values it cannot
+// convert as attribute errors.
+type priorState struct {
+	attrs map[string]json.RawMessage
+	path  path.Path
+	diags *diag.Diagnostics
+}
+
+// newPriorState decodes the raw state of a resource instance.
+func newPriorState(raw *tfprotov6.RawState, diags *diag.Diagnostics) priorState {
+	s := priorState{path: path.Empty(), diags: diags}
+
+	if raw == nil || raw.JSON == nil {
+		diags.AddError(
+			"Unsupported Prior State",
+			"The prior state is not in JSON format. Run terraform refresh with Terraform 0.12 or later and the v0.x provider before upgrading.",
+		)
+		return s
+	}
+
+	if err := json.Unmarshal(raw.JSON, &s.attrs); err != nil {
+		diags.AddError("Invalid Prior State", fmt.Sprintf("Could not decode prior state: %s", err.Error()))
+	}
+	return s
+}
+
+// value returns the JSON value of an attribute, or nil if it is missing or null.
+func (s priorState) value(name string) json.RawMessage {
+	value, ok := s.attrs[name]
+	if !ok || string(value) == "null" {
+		return nil
+	}
+	return value
+}
+
+// invalid reports an attribute whose prior value cannot be converted.
+func (s priorState) invalid(name, expected string, value json.RawMessage) {
+	s.diags.AddAttributeError(
+		s.path.AtName(name),
+		"Invalid Prior State",
+		fmt.Sprintf("Expected %s in prior state, got: %s", expected, value),
+	)
+}
+
+// String returns a string attribute. Numbers are converted to their decimal
+// form, and "" is treated as null.
+func (s priorState) String(name string) types.String {
+	value := s.value(name)
+	if value == nil {
+		return types.StringNull()
+	}
+
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		if str == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(str)
+	}
+
+	var num json.Number
+	if err := json.Unmarshal(value, &num); err == nil {
+		return types.StringValue(num.String())
+	}
+
+	s.invalid(name, "a string", value)
+	return types.StringNull()
+}
+
+// Bool returns a boolean attribute, which may be stored as a string.
+func (s priorState) Bool(name string) types.Bool {
+	value := s.value(name)
+	if value == nil {
+		return types.BoolNull()
+	}
+
+	var b bool
+	if err := json.Unmarshal(value, &b); err == nil {
+		return types.BoolValue(b)
+	}
+
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		if str == "" {
+			return types.BoolNull()
+		}
+		if b, err := strconv.ParseBool(str); err == nil {
+			return types.BoolValue(b)
+		}
+	}
+
+	s.invalid(name, "a boolean", value)
+	return types.BoolNull()
+}
+
+// Int64 returns an integer attribute, which may be stored as a string.
+func (s priorState) Int64(name string) types.Int64 {
+	str := s.String(name)
+	if str.IsNull() {
+		return types.Int64Null()
+	}
+
+	i, err := strconv.ParseInt(str.ValueString(), 10, 64)
+	if err != nil {
+		s.invalid(name, "an integer", s.value(name))
+		return types.Int64Null()
+	}
+	return types.Int64Value(i)
+}
+
+// Float64 returns a number attribute, which may be stored as a string.
+func (s priorState) Float64(name string) types.Float64 {
+	str := s.String(name)
+	if str.IsNull() {
+		return types.Float64Null()
+	}
+
+	f, err := strconv.ParseFloat(str.ValueString(), 64)
+	if err != nil {
+		s.invalid(name, "a number", s.value(name))
+		return types.Float64Null()
+	}
+	return types.Float64Value(f)
+}
+
+// Strings returns a list or set of strings, or nil if it is null or empty.
+func (s priorState) Strings(name string) []string {
+	value := s.value(name)
+	if value == nil {
+		return nil
+	}
+
+	var strs []string
+	if err := json.Unmarshal(value, &strs); err != nil {
+		s.invalid(name, "a list of strings", value)
+		return nil
+	}
+	if len(strs) == 0 {
+		return nil
+	}
+	return strs
+}
+
+// Object returns a single nested object, which the SDK stored as a list of
+// one element. The second result is false if the object is null or absent.
+func (s priorState) Object(name string) (priorState, bool) {
+	value := s.value(name)
+	if value == nil {
+		return priorState{}, false
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(value, &list); err == nil {
+		if len(list) == 0 {
+			return priorState{}, false
+		}
+		if len(list) > 1 {
+			s.invalid(name, "a single object", value)
+			return priorState{}, false
+		}
+		value = list[0]
+	}
+
+	obj := priorState{path: s.path.AtName(name), diags: s.diags}
+	if err := json.Unmarshal(value, &obj.attrs); err != nil {
+		s.invalid(name, "an object", value)
+		return priorState{}, false
+	}
+	return obj, obj.attrs != nil
+}
+
+// Objects returns a list or set of nested objects, or nil if it is null or empty.
+func (s priorState) Objects(name string) []priorState {
+	value := s.value(name)
+	if value == nil {
+		return nil
+	}
+
+	var list []map[string]json.RawMessage
+	if err := json.Unmarshal(value, &list); err != nil {
+		s.invalid(name, "a list of objects", value)
+		return nil
+	}
+
+	objs := make([]priorState, 0, len(list))
+	for i, attrs := range list {
+		objs = append(objs, priorState{attrs: attrs, path: s.path.AtName(name).AtListIndex(i), diags: s.diags})
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+	return objs
+}
+
+// Timeouts returns the timeouts block with the given operations. The SDK
+// releases had no timeouts block, so it is usually null.
+func (s priorState) Timeouts(operations ...string) timeouts.Value {
+	attrTypes := make(map[string]attr.Type, len(operations))
+	for _, operation := range operations {
+		attrTypes[operation] = types.StringType
+	}
+
+	block, ok := s.Object("timeouts")
+	if !ok {
+		return timeouts.Value{Object: types.ObjectNull(attrTypes)}
+	}
+
+	values := make(map[string]attr.Value, len(operations))
+	for _, operation := range operations {
+		values[operation] = block.String(operation)
+	}
+
+	obj, diags := types.ObjectValue(attrTypes, values)
+	s.diags.Append(diags...)
+	return timeouts.Value{Object: obj}
+}
+
+// formerTypeNames lists, by current resource type name, the type names earlier
+// releases wrote the resource's state under. moved blocks from these types are
+// accepted as well as from the current name. MIGRATION.md documents no renamed
+// resource types, so none are listed yet.
+var formerTypeNames = map[string][]string{}
+
+// moveStateFromCrucible returns a StateMover that accepts state of typeName, or
+// of one of its formerNames, written by any release of this provider, including
+// under another source address such as a private registry mirror or a fork.
+// Prior schema versions are brought up to date with upgraders, the resource's
+// UpgradeState.
+func moveStateFromCrucible(typeName string, formerNames []string, upgraders map[int64]resource.StateUpgrader) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			// Leaving TargetState unset tells the framework this mover does not apply.
+			knownType := req.SourceTypeName == typeName || slices.Contains(formerNames, req.SourceTypeName)
+			if !knownType || !isCrucibleProviderAddress(req.SourceProviderAddress) {
+				return
+			}
+
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError("Missing Source State", fmt.Sprintf("Cannot move %s without its source state.", typeName))
+				return
+			}
+
+			if req.SourceSchemaVersion == resp.TargetState.Schema.GetVersion() {
+				value, err := req.SourceRawState.Unmarshal(resp.TargetState.Schema.Type().TerraformType(ctx))
+				if err != nil {
+					resp.Diagnostics.AddError("Invalid Source State", fmt.Sprintf("Could not decode %s source state: %s", typeName, err.Error()))
+					return
+				}
+				resp.TargetState.Raw = value
+				return
+			}
+
+			upgrader, ok := upgraders[req.SourceSchemaVersion]
+			if !ok {
+				resp.Diagnostics.AddError(
+					"Unsupported Source Schema Version",
+					fmt.Sprintf("Cannot move %s state with schema version %d. Upgrade the source provider first.", typeName, req.SourceSchemaVersion),
+				)
+				return
+			}
+
+			state, diags := runStateUpgrader(ctx, upgrader, req.SourceRawState, resp.TargetState)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			resp.TargetState = state
+		},
+	}
+}
+
+// runStateUpgrader converts raw prior state with upgrader into target, an
+// empty state of the current schema, as the framework does for UpgradeState.
+func runStateUpgrader(ctx context.Context, upgrader resource.StateUpgrader, raw *tfprotov6.RawState, target tfsdk.State) (tfsdk.State, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	req := resource.UpgradeStateRequest{RawState: raw}
+
+	if upgrader.PriorSchema != nil {
+		value, err := raw.Unmarshal(upgrader.PriorSchema.Type().TerraformType(ctx))
+		if err != nil {
+			diags.AddError("Invalid Prior State", fmt.Sprintf("Could not decode prior state: %s", err.Error()))
+			return target, diags
+		}
+		req.State = &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: value}
+	}
+
+	resp := resource.UpgradeStateResponse{State: target}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	diags.Append(resp.Diagnostics...)
+	return resp.State, diags
+}
+
+// isCrucibleProviderAddress reports whether address, such as
+// registry.terraform.io/cmu-sei/crucible, refers to a Crucible provider.
+func isCrucibleProviderAddress(address string) bool {
+	return address == "crucible" || strings.HasSuffix(address, "/crucible")
+}
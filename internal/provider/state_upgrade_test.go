@@ -0,0 +1,177 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readStateFixture returns the prior state JSON in testdata/state/fixture.
+func readStateFixture(t *testing.T, fixture string) *tfprotov6.RawState {
+	t.Helper()
+
+	raw, err := os.ReadFile(filepath.Join("testdata", "state", fixture))
+	if err != nil {
+		t.Fatalf("Failed to read state fixture: %v", err)
+	}
+	return &tfprotov6.RawState{JSON: raw}
+}
+
+// emptyState returns a null state of the resource's current schema.
+func emptyState(ctx context.Context, r resource.Resource) tfsdk.State {
+	var resp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &resp)
+
+	return tfsdk.State{
+		Schema: resp.Schema,
+		Raw:    tftypes.NewValue(resp.Schema.Type().TerraformType(ctx), nil),
+	}
+}
+
+// upgradeStateFixture upgrades the prior state in testdata/state/fixture with
+// the resource's upgrader for version, and decodes the result into target.
+func upgradeStateFixture(t *testing.T, r resource.ResourceWithUpgradeState, version int64, fixture string, target any) {
+	t.Helper()
+	ctx := context.Background()
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("No state upgrader for version %d", version)
+	}
+
+	state, diags := runStateUpgrader(ctx, upgrader, readStateFixture(t, fixture), emptyState(ctx, r))
+	if diags.HasError() {
+		t.Fatalf("Upgrading %s returned errors: %v", fixture, diags)
+	}
+
+	if diags := state.Get(ctx, target); diags.HasError() {
+		t.Fatalf("Failed to read upgraded state: %v", diags)
+	}
+}
+
+// TestPriorState verifies that SDK and framework forms of prior values are both accepted
+func TestPriorState(t *testing.T) {
+	var diags diag.Diagnostics
+	prior := newPriorState(&tfprotov6.RawState{JSON: []byte(`{
+		"empty": "",
+		"number": 100,
+		"bool_string": "true",
+		"bool": false,
+		"int_string": "10",
+		"list_object": [{"name": "a"}],
+		"empty_list": [],
+		"object": {"name": "b"},
+		"strings": ["x", "y"],
+		"bad_bool": "maybe"
+	}`)}, &diags)
+
+	if !prior.String("empty").IsNull() {
+		t.Errorf("Expected \"\" to decode as null")
+	}
+	if !prior.String("missing").IsNull() {
+		t.Errorf("Expected a missing attribute to decode as null")
+	}
+	if got := prior.String("number"); !got.Equal(types.StringValue("100")) {
+		t.Errorf("Expected number to decode as \"100\", got %s", got)
+	}
+	if got := prior.Bool("bool_string"); !got.Equal(types.BoolValue(true)) {
+		t.Errorf("Expected \"true\" to decode as true, got %s", got)
+	}
+	if got := prior.Bool("bool"); !got.Equal(types.BoolValue(false)) {
+		t.Errorf("Expected false to decode as false, got %s", got)
+	}
+	if got := prior.Int64("int_string"); !got.Equal(types.Int64Value(10)) {
+		t.Errorf("Expected \"10\" to decode as 10, got %s", got)
+	}
+	if obj, ok := prior.Object("list_object"); !ok || obj.String("name").ValueString() != "a" {
+		t.Errorf("Expected a list of one object to decode as that object")
+	}
+	if _, ok := prior.Object("empty_list"); ok {
+		t.Errorf("Expected an empty list to decode as no object")
+	}
+	if obj, ok := prior.Object("object"); !ok || obj.String("name").ValueString() != "b" {
+		t.Errorf("Expected an object to decode as itself")
+	}
+	if got := prior.Strings("strings"); len(got) != 2 || got[0] != "x" || got[1] != "y" {
+		t.Errorf("Unexpected strings: %v", got)
+	}
+	if prior.Objects("empty_list") != nil {
+		t.Errorf("Expected an empty list to decode as nil")
+	}
+	if diags.HasError() {
+		t.Fatalf("Unexpected errors: %v", diags)
+	}
+
+	prior.Bool("bad_bool")
+	if !diags.HasError() {
+		t.Errorf("Expected an error for an invalid boolean")
+	}
+}
+
+// TestMoveStateFromCrucible verifies which sources the state mover accepts
+func TestMoveStateFromCrucible(t *testing.T) {
+	ctx := context.Background()
+	r := &playerUserResource{}
+	mover := moveStateFromCrucible("crucible_player_user", []string{"crucible_user"}, r.UpgradeState(ctx))
+
+	move := func(typeName, address string, version int64, raw *tfprotov6.RawState) *resource.MoveStateResponse {
+		resp := &resource.MoveStateResponse{TargetState: emptyState(ctx, r)}
+		mover.StateMover(ctx, resource.MoveStateRequest{
+			SourceTypeName:        typeName,
+			SourceProviderAddress: address,
+			SourceSchemaVersion:   version,
+			SourceRawState:        raw,
+		}, resp)
+		return resp
+	}
+
+	resp := move("crucible_player_user", "registry.example.com/cmu-sei/crucible", 0, readStateFixture(t, "player_user_v0_sdk.json"))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Moving version 0 state returned errors: %v", resp.Diagnostics)
+	}
+	var moved playerUserResourceModel
+	if diags := resp.TargetState.Get(ctx, &moved); diags.HasError() {
+		t.Fatalf("Failed to read moved state: %v", diags)
+	}
+	if moved.Name.ValueString() != "jdoe" || !moved.Role.IsNull() || moved.OnConflict.ValueString() != onConflictError {
+		t.Errorf("Unexpected moved state: %+v", moved)
+	}
+
+	current := &tfprotov6.RawState{JSON: []byte(`{"id": "u1", "user_id": "u1", "name": "jdoe", "role": "Admin", "on_conflict": "adopt", "timeouts": null}`)}
+	resp = move("crucible_player_user", "registry.terraform.io/cmu-sei/crucible", 1, current)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Moving current state returned errors: %v", resp.Diagnostics)
+	}
+	if diags := resp.TargetState.Get(ctx, &moved); diags.HasError() || moved.Role.ValueString() != "Admin" {
+		t.Errorf("Unexpected moved state: %+v (%v)", moved, diags)
+	}
+
+	resp = move("crucible_user", "registry.terraform.io/cmu-sei/crucible", 0, readStateFixture(t, "player_user_v0_sdk.json"))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Moving state from a former type name returned errors: %v", resp.Diagnostics)
+	}
+	if diags := resp.TargetState.Get(ctx, &moved); diags.HasError() || moved.Name.ValueString() != "jdoe" {
+		t.Errorf("Unexpected state moved from a former type name: %+v (%v)", moved, diags)
+	}
+
+	for _, source := range []struct{ typeName, address string }{
+		{"crucible_vlan", "registry.terraform.io/cmu-sei/crucible"},
+		{"crucible_player_user", "registry.terraform.io/example/other"},
+	} {
+		resp := move(source.typeName, source.address, 0, readStateFixture(t, "player_user_v0_sdk.json"))
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			t.Errorf("Expected %s from %s to be ignored", source.typeName, source.address)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/tracing"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// startSpan starts a span for a resource operation. The returned function ends
+// the span, marking it as failed if diags contains errors by then.
+func startSpan(ctx context.Context, name string, diags *diag.Diagnostics) (context.Context, func()) {
+	ctx, span := tracing.Start(ctx, name)
+	return ctx, func() {
+		if diags.HasError() {
+			for _, d := range diags.Errors() {
+				span.AddEvent(d.Summary())
+			}
+			span.SetStatus(codes.Error, diags.Errors()[0].Summary())
+		}
+		span.End()
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cmu-sei/terraform-provider-crucible/internal/validators"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &uiURLFunction{}
+
+// NewPlayerViewURLFunction returns the function that builds the Player UI link to a view.
+func NewPlayerViewURLFunction() function.Function {
+	return &uiURLFunction{
+		name:    "player_view_url",
+		summary: "Build the Player URL of a view",
+		description: "Returns the URL at which participants open a view in the Player UI. " +
+			"Provider functions cannot read the provider configuration, so the Player UI URL is an argument.",
+		uiName:        "player_url",
+		uiDescription: "The URL of the Player UI, such as \"https://player.example.com\". This is not the Player API URL.",
+		idName:        "view_id",
+		idDescription: "The ID of the view.",
+		route:         "view/%s",
+	}
+}
+
+// NewVMConsoleURLFunction returns the function that builds the console UI link to a virtual machine.
+func NewVMConsoleURLFunction() function.Function {
+	return &uiURLFunction{
+		name:    "vm_console_url",
+		summary: "Build the console URL of a virtual machine",
+		description: "Returns the URL of a virtual machine's console in the Crucible console UI, in the same form as the VM API's default URL. " +
+			"Provider functions cannot read the provider configuration, so the console UI URL is an argument.",
+		uiName:        "console_url",
+		uiDescription: "The URL of the console UI, such as \"https://console.example.com\".",
+		idName:        "vm_id",
+		idDescription: "The ID of the virtual machine.",
+		route:         "vm/%s/console",
+	}
+}
+
+// uiURLFunction builds the link to an object in a Crucible UI from the URL of
+// the UI and the object's ID.
+type uiURLFunction struct {
+	name        string
+	summary     string
+	description string
+
+	uiName        string
+	uiDescription string
+	idName        string
+	idDescription string
+
+	// route is the path of the object below the UI URL, with %s for its ID
+	route string
+}
+
+// Metadata returns the function name.
+func (f *uiURLFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *uiURLFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     f.summary,
+		Description: f.description,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        f.uiName,
+				Description: f.uiDescription,
+				Validators: []function.StringParameterValidator{
+					validators.AbsoluteURLParameter(),
+				},
+			},
+			function.StringParameter{
+				Name:        f.idName,
+				Description: f.idDescription,
+				Validators: []function.StringParameterValidator{
+					validators.UUIDParameter(),
+				},
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run joins the UI URL and the route of the object.
+func (f *uiURLFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uiURL, id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &uiURL, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, strings.TrimSuffix(uiURL, "/")+"/"+fmt.Sprintf(f.route, id)))
+}
@@ -11,15 +11,15 @@ import (
 
 // VMInfo used as the payload for VM creation and as the return value for VM retrieval
 type VMInfo struct {
-	ID         string              `json:"id"`
-	URL        string              `json:"url,omitempty"`
-	DefaultURL bool                `json:"defaultUrl,omitempty"`
-	Name       string              `json:"name"`
-	TeamIDs    []string            `json:"teamIds,omitempty"`
-	UserID     *string             `json:"userId,omitempty"`
-	Embeddable bool                `json:"embeddable,omitempty"`
-	Connection *ConsoleConnection  `json:"consoleConnectionInfo,omitempty"`
-	Proxmox    *ProxmoxInfo        `json:"proxmoxVmInfo,omitempty"`
+	ID         string             `json:"id"`
+	URL        string             `json:"url,omitempty"`
+	DefaultURL bool               `json:"defaultUrl,omitempty"`
+	Name       string             `json:"name"`
+	TeamIDs    []string           `json:"teamIds,omitempty"`
+	UserID     *string            `json:"userId,omitempty"`
+	Embeddable bool               `json:"embeddable,omitempty"`
+	Connection *ConsoleConnection `json:"consoleConnectionInfo,omitempty"`
+	Proxmox    *ProxmoxInfo       `json:"proxmoxVmInfo,omitempty"`
 }
 
 // ConsoleConnection represents a console connection info block
@@ -40,6 +40,7 @@ type ProxmoxInfo struct {
 
 // ViewInfo used as payload for view creation and return value for view retrieval
 type ViewInfo struct {
+	ID              string     `json:"id,omitempty"`
 	Name            string     `json:"name"`
 	Description     string     `json:"description,omitempty"`
 	Status          string     `json:"status,omitempty"`
@@ -91,6 +92,7 @@ func UserHasID(arr []UserInfo, id string) bool {
 
 // AppTemplate holds the information needed for CRUD operations on an ApplicationTemplate resource
 type AppTemplate struct {
+	ID               string `json:"id,omitempty"`
 	Name             string `json:"name"`
 	URL              string `json:"url,omitempty"`
 	Icon             string `json:"icon,omitempty"`
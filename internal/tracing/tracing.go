@@ -0,0 +1,105 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+// Package tracing configures optional OpenTelemetry tracing for the provider.
+// Tracing is disabled unless SEI_CRUCIBLE_TRACING is set to "otlp" or "file".
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// EnvExporter selects the span exporter: "otlp" sends spans to the endpoint
+	// in the standard OTEL_EXPORTER_OTLP_* variables, "file" writes JSON spans to EnvFile.
+	EnvExporter = "SEI_CRUCIBLE_TRACING"
+
+	// EnvFile is the path spans are written to when EnvExporter is "file"
+	EnvFile = "SEI_CRUCIBLE_TRACING_FILE"
+
+	defaultFile = "crucible-traces.json"
+	tracerName  = "github.com/cmu-sei/terraform-provider-crucible"
+)
+
+// Init configures the global tracer provider and W3C trace context propagation
+// from the environment. The returned function flushes and stops the exporter;
+// it is a no-op if tracing is disabled.
+func Init(ctx context.Context, version string) (func(context.Context) error, error) {
+	exporterName := strings.ToLower(os.Getenv(EnvExporter))
+	if exporterName == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var processor sdktrace.SpanProcessor
+	switch exporterName {
+	case "otlp":
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+		}
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	case "file":
+		path := os.Getenv(EnvFile)
+		if path == "" {
+			path = defaultFile
+		}
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+		}
+
+		// Export synchronously so spans are not lost when Terraform stops the provider
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	default:
+		return nil, fmt.Errorf("unsupported %s value %q: must be \"otlp\" or \"file\"", EnvExporter, exporterName)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName("terraform-provider-crucible"),
+			semconv.ServiceVersion(version),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return provider.Shutdown, nil
+}
+
+// Start starts a span using the global tracer provider. Spans are no-ops when
+// tracing is disabled.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
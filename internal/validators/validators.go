@@ -0,0 +1,157 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+// Package validators provides schema validators shared by the Crucible
+// resources, so that malformed IDs, URLs and enum values are reported at plan
+// time instead of being rejected by the API partway through an apply.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// uuidPattern matches the lowercase, hyphenated UUIDs used by the Crucible APIs.
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// rolePattern matches a role name without leading or trailing whitespace.
+var rolePattern = regexp.MustCompile(`^\S(.*\S)?$`)
+
+// View statuses accepted by the Player API.
+var viewStatuses = []string{"Active", "Inactive"}
+
+// Console protocols supported by Guacamole connections.
+var consoleProtocols = []string{"ssh", "vnc", "rdp"}
+
+// UUID validates that a string is a lowercase, hyphenated UUID.
+func UUID() validator.String {
+	return stringvalidator.RegexMatches(uuidPattern, "must be a valid UUID (lowercase with hyphens)")
+}
+
+// ViewStatus validates that a string is a Player view status.
+func ViewStatus() validator.String {
+	return stringvalidator.OneOf(viewStatuses...)
+}
+
+// ConsoleProtocol validates that a string is a supported console protocol.
+func ConsoleProtocol() validator.String {
+	return stringvalidator.OneOf(consoleProtocols...)
+}
+
+// RoleName validates that a string can be a Player role name. Roles can be
+// created in Player at any time, so the name itself is resolved by the API.
+func RoleName() validator.String {
+	return stringvalidator.RegexMatches(rolePattern, "must be a role name without leading or trailing whitespace")
+}
+
+// AbsoluteURL validates that a string is an absolute http or https URL.
+func AbsoluteURL() validator.String {
+	return absoluteURLValidator{}
+}
+
+// UUIDParameter validates that a provider function argument is a lowercase,
+// hyphenated UUID.
+func UUIDParameter() function.StringParameterValidator {
+	return uuidParameterValidator{}
+}
+
+// AbsoluteURLParameter validates that a provider function argument is an
+// absolute http or https URL.
+func AbsoluteURLParameter() function.StringParameterValidator {
+	return absoluteURLValidator{}
+}
+
+// Port validates that a string is a TCP port number between 1 and 65535.
+func Port() validator.String {
+	return portValidator{}
+}
+
+// absoluteURLValidator implements AbsoluteURL.
+type absoluteURLValidator struct{}
+
+func (v absoluteURLValidator) Description(_ context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v absoluteURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v absoluteURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !isAbsoluteURL(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid URL",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
+
+func (v absoluteURLValidator) ValidateParameterString(ctx context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	if req.Value.IsNull() || req.Value.IsUnknown() {
+		return
+	}
+
+	value := req.Value.ValueString()
+	if !isAbsoluteURL(value) {
+		resp.Error = function.NewArgumentFuncError(req.ArgumentPosition, fmt.Sprintf("Invalid URL: %s, got: %q", v.Description(ctx), value))
+	}
+}
+
+// isAbsoluteURL reports whether value is an absolute http or https URL.
+func isAbsoluteURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// uuidParameterValidator implements UUIDParameter.
+type uuidParameterValidator struct{}
+
+func (v uuidParameterValidator) ValidateParameterString(_ context.Context, req function.StringParameterValidatorRequest, resp *function.StringParameterValidatorResponse) {
+	if req.Value.IsNull() || req.Value.IsUnknown() {
+		return
+	}
+
+	if value := req.Value.ValueString(); !uuidPattern.MatchString(value) {
+		resp.Error = function.NewArgumentFuncError(req.ArgumentPosition, fmt.Sprintf("Invalid UUID: value must be a valid UUID (lowercase with hyphens), got: %q", value))
+	}
+}
+
+// portValidator implements Port.
+type portValidator struct{}
+
+func (v portValidator) Description(_ context.Context) string {
+	return "value must be a port number between 1 and 65535"
+}
+
+func (v portValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v portValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Port",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Carnegie Mellon University. All Rights Reserved.
+// Released under a MIT (SEI)-style license. See LICENSE.md in the project root for license information.
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestStringValidators verifies which values each validator accepts
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{"uuid valid", UUID(), types.StringValue("46420756-9421-41b7-99b4-1b6d2cba29b3"), false},
+		{"uuid uppercase", UUID(), types.StringValue("46420756-9421-41B7-99B4-1B6D2CBA29B3"), true},
+		{"uuid typo", UUID(), types.StringValue("46420756-9421-41b7-99b4-1b6d2cba29b"), true},
+		{"uuid null", UUID(), types.StringNull(), false},
+		{"uuid unknown", UUID(), types.StringUnknown(), false},
+		{"url https", AbsoluteURL(), types.StringValue("https://player.example.com/views"), false},
+		{"url http with port", AbsoluteURL(), types.StringValue("http://guac.example.local:8080/guacamole"), false},
+		{"url relative", AbsoluteURL(), types.StringValue("/guacamole"), true},
+		{"url no scheme", AbsoluteURL(), types.StringValue("guac.example.com"), true},
+		{"url other scheme", AbsoluteURL(), types.StringValue("ftp://example.com"), true},
+		{"status active", ViewStatus(), types.StringValue("Active"), false},
+		{"status lowercase", ViewStatus(), types.StringValue("active"), true},
+		{"protocol rdp", ConsoleProtocol(), types.StringValue("rdp"), false},
+		{"protocol telnet", ConsoleProtocol(), types.StringValue("telnet"), true},
+		{"role valid", RoleName(), types.StringValue("View Member"), false},
+		{"role padded", RoleName(), types.StringValue("View Member "), true},
+		{"role empty", RoleName(), types.StringValue(""), true},
+		{"port valid", Port(), types.StringValue("3389"), false},
+		{"port zero", Port(), types.StringValue("0"), true},
+		{"port too large", Port(), types.StringValue("65536"), true},
+		{"port not a number", Port(), types.StringValue("ssh"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("test"),
+				ConfigValue: test.value,
+			}
+			resp := &validator.StringResponse{}
+
+			test.validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != test.wantError {
+				t.Errorf("Expected error %v for %s, got diagnostics: %v", test.wantError, test.value, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestParameterValidators verifies which provider function arguments each validator accepts
+func TestParameterValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator function.StringParameterValidator
+		value     types.String
+		wantError bool
+	}{
+		{"uuid valid", UUIDParameter(), types.StringValue("46420756-9421-41b7-99b4-1b6d2cba29b3"), false},
+		{"uuid uppercase", UUIDParameter(), types.StringValue("46420756-9421-41B7-99B4-1B6D2CBA29B3"), true},
+		{"uuid unknown", UUIDParameter(), types.StringUnknown(), false},
+		{"url https", AbsoluteURLParameter(), types.StringValue("https://player.example.com"), false},
+		{"url no scheme", AbsoluteURLParameter(), types.StringValue("player.example.com"), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := function.StringParameterValidatorRequest{
+				ArgumentPosition: 1,
+				Value:            test.value,
+			}
+			resp := &function.StringParameterValidatorResponse{}
+
+			test.validator.ValidateParameterString(context.Background(), req, resp)
+
+			if (resp.Error != nil) != test.wantError {
+				t.Errorf("Expected error %v for %s, got: %v", test.wantError, test.value, resp.Error)
+			}
+		})
+	}
+}